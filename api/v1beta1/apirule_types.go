@@ -48,6 +48,81 @@ type APIRuleSpec struct {
 	// Rules represents collection of Rule to apply
 	// +kubebuilder:validation:MinItems=1
 	Rules []Rule `json:"rules"`
+	// Tracing configuration for the exposed workloads
+	// +optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+	// Access logging of the requests to the exposed workloads
+	// +optional
+	AccessLogging *AccessLogging `json:"accessLogging,omitempty"`
+	// CORS policy of all rules, overwrites the default CORS policy of the controller if defined
+	// +optional
+	CorsPolicy *CorsPolicy `json:"corsPolicy,omitempty"`
+	// Timeout of the requests of all rules, e.g. 30s. Overwrites the default timeout of the controller if defined
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Retries of the requests of all rules
+	// +optional
+	Retries *Retries `json:"retries,omitempty"`
+}
+
+// Retries defines how failed requests are retried by the ingress gateway
+type Retries struct {
+	// Number of retries of a request
+	Attempts int32 `json:"attempts"`
+	// Timeout of each attempt, e.g. 2s
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+	// Comma-separated list of the conditions on which a request is retried, e.g. 5xx,connect-failure
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
+// CorsPolicy defines the cross-origin requests allowed by the exposed workloads
+type CorsPolicy struct {
+	// Origins that are allowed to make cross-origin requests
+	// +optional
+	AllowOrigins []StringMatch `json:"allowOrigins,omitempty"`
+	// HTTP methods that are allowed in cross-origin requests
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// HTTP headers that are allowed in cross-origin requests
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// HTTP headers of the response that are exposed to the browser
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// Defines if credentials are allowed in cross-origin requests
+	// +optional
+	AllowCredentials *bool `json:"allowCredentials,omitempty"`
+	// Duration for which the result of a preflight request is cached, e.g. 24h
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// StringMatch matches a string exactly, by prefix or by regular expression. Exactly one of the fields must be set
+type StringMatch struct {
+	// +optional
+	Exact string `json:"exact,omitempty"`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// +optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// Tracing defines the sampling and the custom tags of the traces of the exposed workloads
+type Tracing struct {
+	// Percentage of requests that are sampled
+	SamplingRate int32 `json:"samplingRate"`
+	// Tags with literal values that are added to the spans
+	// +optional
+	CustomTags map[string]string `json:"customTags,omitempty"`
+}
+
+// AccessLogging enables the access logs of the exposed workloads
+type AccessLogging struct {
+	// Name of the Istio extension provider that writes the access logs. Uses the provider of the controller configuration if not defined
+	// +optional
+	Provider string `json:"provider,omitempty"`
 }
 
 // APIRuleStatus defines the observed state of ApiRule
@@ -121,6 +196,41 @@ type Rule struct {
 	// Mutators to be used
 	// +optional
 	Mutators []*Mutator `json:"mutators,omitempty"`
+	// Fault to be injected into the traffic of the rule. Fault injection must be enabled in the api-gateway-config ConfigMap
+	// +optional
+	Fault *Fault `json:"fault,omitempty"`
+	// Timeout of the requests, overwrites spec level timeout if defined
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Retries of the requests, overwrites spec level retries if defined
+	// +optional
+	Retries *Retries `json:"retries,omitempty"`
+}
+
+// Fault defines the delay and abort faults injected into the requests of a rule
+type Fault struct {
+	// Delay to be injected before forwarding the request
+	// +optional
+	Delay *FaultDelay `json:"delay,omitempty"`
+	// Abort to be returned instead of forwarding the request
+	// +optional
+	Abort *FaultAbort `json:"abort,omitempty"`
+}
+
+// FaultDelay .
+type FaultDelay struct {
+	// Fixed delay before forwarding the request, e.g. 5s
+	FixedDelay metav1.Duration `json:"fixedDelay"`
+	// Percentage of requests on which the delay is injected
+	Percentage int32 `json:"percentage"`
+}
+
+// FaultAbort .
+type FaultAbort struct {
+	// HTTP status code returned to the caller
+	HttpStatus int32 `json:"httpStatus"`
+	// Percentage of requests which are aborted
+	Percentage int32 `json:"percentage"`
 }
 
 // APIRuleResourceStatus .
@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
 
@@ -23,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -150,6 +153,420 @@ var _ = Describe("Controller", func() {
 				Expect(apiRule.Status.APIRuleStatus.Description).To(Equal(`Validation error: Attribute "": Unsupported JWT Handler: foo`))
 			})
 
+			It("should retry adding the finalizer when the APIRule was modified concurrently", func() {
+				testAPI := getApiRule("noop", nil)
+
+				fakeClient := &conflictingClient{Client: getTestSuite(testAPI).mgr.GetClient(), conflicts: 1}
+				ts = &testSuite{mgr: getFakeManager(fakeClient, scheme.Scheme)}
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.updates).To(Equal(2))
+
+				apiRule := gatewayv1beta1.APIRule{}
+				err = ts.mgr.GetClient().Get(context.Background(), types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}, &apiRule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apiRule.Finalizers).To(ContainElement(controllers.API_GATEWAY_FINALIZER))
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+			})
+
+			It("should not retry adding the finalizer on errors other than conflicts", func() {
+				testAPI := getApiRule("noop", nil)
+
+				fakeClient := &conflictingClient{Client: getTestSuite(testAPI).mgr.GetClient(), updateErr: fmt.Errorf("update failed")}
+				ts = &testSuite{mgr: getFakeManager(fakeClient, scheme.Scheme)}
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.updates).To(Equal(1))
+
+				apiRule := gatewayv1beta1.APIRule{}
+				err = ts.mgr.GetClient().Get(context.Background(), types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}, &apiRule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apiRule.Finalizers).To(BeEmpty())
+			})
+
+			It("should not reconcile subresources of a suspended APIRule", func() {
+				testAPI := getApiRule("noop", nil)
+				testAPI.Annotations = map[string]string{controllers.SUSPEND_ANNOTATION: "true"}
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}})
+				Expect(err).ToNot(HaveOccurred())
+
+				apiRule := gatewayv1beta1.APIRule{}
+				err = ts.mgr.GetClient().Get(context.Background(), types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}, &apiRule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apiRule.Finalizers).To(ContainElement(controllers.API_GATEWAY_FINALIZER))
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
+				Expect(apiRule.Status.APIRuleStatus.Description).To(Equal("Reconciliation is suspended by the gateway.kyma-project.io/suspend annotation"))
+
+				var vsList networkingv1beta1.VirtualServiceList
+				Expect(ts.mgr.GetClient().List(context.Background(), &vsList)).To(Succeed())
+				Expect(vsList.Items).To(BeEmpty())
+			})
+
+			It("should reconcile subresources again when the APIRule is resumed", func() {
+				testAPI := getApiRule("noop", nil)
+				testAPI.Annotations = map[string]string{controllers.SUSPEND_ANNOTATION: "true"}
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}}
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+
+				apiRule := gatewayv1beta1.APIRule{}
+				Expect(ts.mgr.GetClient().Get(context.Background(), request.NamespacedName, &apiRule)).To(Succeed())
+				delete(apiRule.Annotations, controllers.SUSPEND_ANNOTATION)
+				Expect(ts.mgr.GetClient().Update(context.Background(), &apiRule)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ts.mgr.GetClient().Get(context.Background(), request.NamespacedName, &apiRule)).To(Succeed())
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+				Expect(apiRule.Status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+
+				var vsList networkingv1beta1.VirtualServiceList
+				Expect(ts.mgr.GetClient().List(context.Background(), &vsList)).To(Succeed())
+				Expect(vsList.Items).To(HaveLen(1))
+			})
+
+			It("should remove the finalizer of a suspended APIRule that is deleted", func() {
+				testAPI := getApiRule("noop", nil)
+				testAPI.Annotations = map[string]string{controllers.SUSPEND_ANNOTATION: "true"}
+				testAPI.Finalizers = []string{controllers.API_GATEWAY_FINALIZER}
+				deletionTimestamp := metav1.Now()
+				testAPI.DeletionTimestamp = &deletionTimestamp
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}})
+				Expect(err).ToNot(HaveOccurred())
+
+				apiRule := gatewayv1beta1.APIRule{}
+				err = ts.mgr.GetClient().Get(context.Background(), types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}, &apiRule)
+				if err == nil {
+					Expect(apiRule.Finalizers).To(BeEmpty())
+				} else {
+					Expect(apierrs.IsNotFound(err)).To(BeTrue())
+				}
+			})
+			It("should requeue the deletion until the subresources are gone and remove the finalizer afterwards", func() {
+				testAPI := getApiRule("noop", nil)
+				testAPI.Finalizers = []string{controllers.API_GATEWAY_FINALIZER}
+				deletionTimestamp := metav1.Now()
+				testAPI.DeletionTimestamp = &deletionTimestamp
+
+				vs := &networkingv1beta1.VirtualService{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-vs", Namespace: testAPI.Namespace, Labels: processing.GetOwnerLabels(testAPI), Finalizers: []string{"test"}},
+				}
+				ap := &securityv1beta1.AuthorizationPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-ap", Namespace: testAPI.Namespace, Labels: processing.GetOwnerLabels(testAPI)},
+				}
+
+				ts = getTestSuite(testAPI, vs, ap)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ISTIO)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}}
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(controllers.ERROR_RECONCILIATION_PERIOD))
+
+				apiRule := gatewayv1beta1.APIRule{}
+				Expect(ts.mgr.GetClient().Get(ctx, request.NamespacedName, &apiRule)).To(Succeed())
+				Expect(apiRule.Finalizers).To(ContainElement(controllers.API_GATEWAY_FINALIZER))
+				Expect(ts.mgr.GetClient().Get(ctx, client.ObjectKeyFromObject(ap), &securityv1beta1.AuthorizationPolicy{})).To(Succeed())
+
+				Expect(ts.mgr.GetClient().Get(ctx, client.ObjectKeyFromObject(vs), vs)).To(Succeed())
+				vs.Finalizers = nil
+				Expect(ts.mgr.GetClient().Update(ctx, vs)).To(Succeed())
+
+				result, err = reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).ToNot(Equal(controllers.ERROR_RECONCILIATION_PERIOD))
+
+				err = ts.mgr.GetClient().Get(ctx, request.NamespacedName, &apiRule)
+				if err == nil {
+					Expect(apiRule.Finalizers).To(BeEmpty())
+				} else {
+					Expect(apierrs.IsNotFound(err)).To(BeTrue())
+				}
+				err = ts.mgr.GetClient().Get(ctx, client.ObjectKeyFromObject(ap), &securityv1beta1.AuthorizationPolicy{})
+				Expect(apierrs.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should check with the API reader that deleted subresources are gone", func() {
+				testAPI := getApiRule("noop", nil)
+				testAPI.Finalizers = []string{controllers.API_GATEWAY_FINALIZER}
+				deletionTimestamp := metav1.Now()
+				testAPI.DeletionTimestamp = &deletionTimestamp
+
+				vs := &networkingv1beta1.VirtualService{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-vs", Namespace: testAPI.Namespace, Labels: processing.GetOwnerLabels(testAPI)},
+				}
+
+				ts = getTestSuite(testAPI, vs)
+				reconciler := getAPIReconciler(ts.mgr).(*controllers.APIRuleReconciler)
+				// the cache still lists the VirtualService after it is deleted
+				reconciler.Client = &staleListClient{Client: ts.mgr.GetClient(), cache: getTestSuite(vs.DeepCopy()).mgr.GetClient()}
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ISTIO)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}}
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).ToNot(Equal(controllers.ERROR_RECONCILIATION_PERIOD))
+
+				apiRule := gatewayv1beta1.APIRule{}
+				err = ts.mgr.GetClient().Get(ctx, request.NamespacedName, &apiRule)
+				if err == nil {
+					Expect(apiRule.Finalizers).To(BeEmpty())
+				} else {
+					Expect(apierrs.IsNotFound(err)).To(BeTrue())
+				}
+			})
+
+			It("should apply valid config on ConfigMap change", func() {
+				ts = getTestSuite()
+				reconciler := getAPIReconciler(ts.mgr).(*controllers.APIRuleReconciler)
+				reconciler.Config = &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ORY, FaultInjectionEnabled: true}
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ISTIO)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: helpers.CM_NS, Name: helpers.CM_NAME}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reconciler.Config.JWTHandler).To(Equal(helpers.JWT_HANDLER_ISTIO))
+				Expect(reconciler.Config.FaultInjectionEnabled).To(BeFalse())
+			})
+
+			It("should keep last valid config when ConfigMap changes to invalid config", func() {
+				ts = getTestSuite()
+				reconciler := getAPIReconciler(ts.mgr).(*controllers.APIRuleReconciler)
+				reconciler.Config = &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO}
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: "jwtHandler: foo"}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: helpers.CM_NS, Name: helpers.CM_NAME}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reconciler.Config.JWTHandler).To(Equal(helpers.JWT_HANDLER_ISTIO))
+			})
+			It("should show the rejected config in the APIRule status until a valid config is loaded", func() {
+				testAPI := getApiRule("noop", nil)
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr).(*controllers.APIRuleReconciler)
+				reconciler.Config = &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ORY}
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: "jwtHandler: foo"}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: helpers.CM_NS, Name: helpers.CM_NAME}})
+				Expect(err).ToNot(HaveOccurred())
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}})
+				Expect(err).ToNot(HaveOccurred())
+
+				apiRule := gatewayv1beta1.APIRule{}
+				err = ts.mgr.GetClient().Get(context.Background(), types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}, &apiRule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+				Expect(apiRule.Status.APIRuleStatus.Description).To(Equal(fmt.Sprintf(`Warning: the config of ConfigMap %s/%s was rejected, the last valid config is used. Validation error: Attribute "": Unsupported JWT Handler: foo`, helpers.CM_NS, helpers.CM_NAME)))
+
+				fakeReader.Content = fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: helpers.CM_NS, Name: helpers.CM_NAME}})
+				Expect(err).ToNot(HaveOccurred())
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = ts.mgr.GetClient().Get(context.Background(), types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}, &apiRule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+				Expect(apiRule.Status.APIRuleStatus.Description).To(BeEmpty())
+			})
+
+			It("should expand access strategy presets again when the ConfigMap changes", func() {
+				testAPI := getApiRule(processing.PresetHandlerName, getRawConfig(map[string]string{"name": "team-a"}))
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				cmRequest := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: helpers.CM_NS, Name: helpers.CM_NAME}}
+				request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}}
+				reconcileWithPreset := func(handler string) []*rulev1alpha1.Authenticator {
+					fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s\naccessStrategyPresets:\n  team-a:\n  - handler: %s", helpers.JWT_HANDLER_ORY, handler)}
+					helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+					_, err := reconciler.Reconcile(ctx, cmRequest)
+					Expect(err).ToNot(HaveOccurred())
+					_, err = reconciler.Reconcile(ctx, request)
+					Expect(err).ToNot(HaveOccurred())
+
+					apiRule := gatewayv1beta1.APIRule{}
+					Expect(ts.mgr.GetClient().Get(ctx, request.NamespacedName, &apiRule)).To(Succeed())
+					Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+					Expect(apiRule.Spec.Rules[0].AccessStrategies[0].Handler.Name).To(Equal(processing.PresetHandlerName))
+
+					var ruleList rulev1alpha1.RuleList
+					Expect(ts.mgr.GetClient().List(ctx, &ruleList)).To(Succeed())
+					Expect(ruleList.Items).To(HaveLen(1))
+					return ruleList.Items[0].Spec.Authenticators
+				}
+
+				authenticators := reconcileWithPreset("noop")
+				Expect(authenticators).To(HaveLen(1))
+				Expect(authenticators[0].Handler.Name).To(Equal("noop"))
+
+				authenticators = reconcileWithPreset("oauth2_introspection")
+				Expect(authenticators).To(HaveLen(1))
+				Expect(authenticators[0].Handler.Name).To(Equal("oauth2_introspection"))
+			})
+
+			It("should fail if the APIRule references an unknown preset", func() {
+				testAPI := getApiRule(processing.PresetHandlerName, getRawConfig(map[string]string{"name": "unknown"}))
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s", helpers.JWT_HANDLER_ORY)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}}
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+
+				apiRule := gatewayv1beta1.APIRule{}
+				Expect(ts.mgr.GetClient().Get(ctx, request.NamespacedName, &apiRule)).To(Succeed())
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+				Expect(apiRule.Status.APIRuleStatus.Description).To(ContainSubstring("Preset unknown is not defined"))
+
+				var ruleList rulev1alpha1.RuleList
+				Expect(ts.mgr.GetClient().List(ctx, &ruleList)).To(Succeed())
+				Expect(ruleList.Items).To(BeEmpty())
+			})
+
+			It("should persist the normalized rules and match paths with and without trailing slash", func() {
+				testAPI := getApiRule("noop", nil)
+				testAPI.Spec.Rules[0].Path = "//orders/"
+				testAPI.Spec.Rules[0].Methods = []string{"get"}
+
+				ts = getTestSuite(testAPI)
+				reconciler := getAPIReconciler(ts.mgr)
+				ctx := context.Background()
+
+				fakeReader := FakeConfigMapReader{Content: fmt.Sprintf("jwtHandler: %s\ntrailingSlashPolicy: %s", helpers.JWT_HANDLER_ORY, helpers.TRAILING_SLASH_BOTH)}
+				helpers.ReadConfigMapHandle = fakeReader.ReadConfigMap
+
+				defer func() {
+					helpers.ReadConfigMapHandle = helpers.ReadConfigMap
+				}()
+
+				request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testAPI.Namespace, Name: testAPI.Name}}
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).ToNot(HaveOccurred())
+
+				apiRule := gatewayv1beta1.APIRule{}
+				Expect(ts.mgr.GetClient().Get(ctx, request.NamespacedName, &apiRule)).To(Succeed())
+				Expect(apiRule.Status.APIRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+				Expect(apiRule.Spec.Rules[0].Path).To(Equal("/orders"))
+				Expect(apiRule.Spec.Rules[0].Methods).To(Equal([]string{"GET"}))
+
+				var ruleList rulev1alpha1.RuleList
+				Expect(ts.mgr.GetClient().List(ctx, &ruleList)).To(Succeed())
+				Expect(ruleList.Items).To(HaveLen(1))
+				Expect(ruleList.Items[0].Spec.Match.URL).To(Equal("<http|https>://foo.bar</orders/?>"))
+				Expect(ruleList.Items[0].Spec.Match.Methods).To(Equal([]string{"GET"}))
+			})
+
 			Context("when the jwt handler is istio", func() {
 				It("should update status", func() {
 					testAPI := getApiRule("jwt", getJWTIstioConfig())
@@ -247,6 +664,7 @@ func getRawConfig(config any) *runtime.RawExtension {
 func getAPIReconciler(mgr manager.Manager) reconcile.Reconciler {
 	return &controllers.APIRuleReconciler{
 		Client:          mgr.GetClient(),
+		APIReader:       mgr.GetAPIReader(),
 		Log:             ctrl.Log.WithName("controllers").WithName("Api"),
 		DomainAllowList: []string{"bar", "kyma.local"},
 		CorsConfig: &processing.CorsConfig{
@@ -259,6 +677,35 @@ func getAPIReconciler(mgr manager.Manager) reconcile.Reconciler {
 	}
 }
 
+// conflictingClient returns a conflict for the given number of updates, or updateErr for every update if it is set.
+type conflictingClient struct {
+	client.Client
+	conflicts int
+	updateErr error
+	updates   int
+}
+
+func (c *conflictingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.updates++
+	if c.updateErr != nil {
+		return c.updateErr
+	}
+	if c.updates <= c.conflicts {
+		return apierrs.NewConflict(gatewayv1beta1.GroupVersion.WithResource("apirules").GroupResource(), obj.GetName(), fmt.Errorf("the object has been modified"))
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// staleListClient lists the objects from cache, which is not updated by the client, like a cache that is not yet synced.
+type staleListClient struct {
+	client.Client
+	cache client.Client
+}
+
+func (c *staleListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.cache.List(ctx, list, opts...)
+}
+
 type testSuite struct {
 	mgr manager.Manager
 }
@@ -272,6 +719,10 @@ func getTestSuite(objects ...runtime.Object) *testSuite {
 	Expect(err).NotTo(HaveOccurred())
 	err = securityv1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = networkingv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = telemetryv1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	return &testSuite{
 		mgr: getFakeManager(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objects...).Build(), scheme.Scheme),
@@ -345,7 +796,7 @@ func (f fakeManager) GetEventRecorderFor(_ string) record.EventRecorder {
 }
 
 func (f fakeManager) GetAPIReader() client.Reader {
-	return nil
+	return f.client
 }
 
 func (f fakeManager) GetWebhookServer() *webhook.Server {
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"time"
@@ -36,8 +37,11 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -49,6 +53,8 @@ import (
 // APIRuleReconciler reconciles a APIRule object
 type APIRuleReconciler struct {
 	client.Client
+	// APIReader reads from the API server without the cache of the client, e.g. to check that deleted subresources are gone
+	APIReader              client.Reader
 	Log                    logr.Logger
 	OathkeeperSvc          string
 	OathkeeperSvcPort      uint32
@@ -62,6 +68,8 @@ type APIRuleReconciler struct {
 	Config                 *helpers.Config
 	ReconcilePeriod        time.Duration
 	OnErrorReconcilePeriod time.Duration
+	// rejectedConfigFailures are the validation failures of the last loaded config, if it was rejected
+	rejectedConfigFailures []validation.Failure
 }
 
 const (
@@ -72,6 +80,10 @@ const (
 	API_GATEWAY_FINALIZER         = "gateway.kyma-project.io/subresources"
 )
 
+// SUSPEND_ANNOTATION set to "true" on an APIRule stops the reconciliation of its subresources, so that they can be changed
+// by hand, e.g. for debugging. The deletion of the APIRule is still handled.
+const SUSPEND_ANNOTATION = "gateway.kyma-project.io/suspend"
+
 type isApiGatewayConfigMapPredicate struct {
 	Log logr.Logger
 	predicate.Funcs
@@ -102,6 +114,19 @@ func (p isApiGatewayConfigMapPredicate) Generic(e event.GenericEvent) bool {
 	return okCM && configMap.GetNamespace() == CONFIGMAP_NS && configMap.GetName() == CONFIGMAP_NAME
 }
 
+// suspendAnnotationChangedPredicate triggers the reconciliation of an APIRule when it is suspended or resumed, since
+// changes of annotations don't change the generation.
+type suspendAnnotationChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (p suspendAnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	return e.ObjectOld.GetAnnotations()[SUSPEND_ANNOTATION] != e.ObjectNew.GetAnnotations()[SUSPEND_ANNOTATION]
+}
+
 //+kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules/finalizers,verbs=update
@@ -109,9 +134,13 @@ func (p isApiGatewayConfigMapPredicate) Generic(e event.GenericEvent) bool {
 //+kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=rules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.istio.io,resources=authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.istio.io,resources=requestauthentications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=telemetry.istio.io,resources=telemetries,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 
 func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log.Info("Starting reconciliation", "namespacedName", req.NamespacedName.String())
@@ -126,23 +155,8 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	isCMReconcile := req.NamespacedName.String() == types.NamespacedName{Namespace: helpers.CM_NS, Name: helpers.CM_NAME}.String()
 	if isCMReconcile || r.Config.JWTHandler == "" {
 		r.Log.Info("Starting ConfigMap reconciliation")
-		err := r.Config.ReadFromConfigMap(ctx, r.Client)
-		if err != nil {
-			if apierrs.IsNotFound(err) {
-				r.Log.Info(fmt.Sprintf(`ConfigMap %s in namespace %s was not found {"controller": "Api"}, will use default config`, helpers.CM_NAME, helpers.CM_NS))
-				r.Config.ResetToDefault()
-			} else {
-				r.Log.Error(err, fmt.Sprintf(`could not read ConfigMap %s in namespace %s {"controller": "Api"}`, helpers.CM_NAME, helpers.CM_NS))
-				r.Config.Reset()
-			}
-		}
+		r.loadConfig(ctx, &validator)
 		if isCMReconcile {
-			configValidationFailures := validator.ValidateConfig(r.Config)
-			r.Log.Info("ConfigMap changed", "config", r.Config)
-			if len(configValidationFailures) > 0 {
-				failuresJson, _ := json.Marshal(configValidationFailures)
-				r.Log.Error(err, fmt.Sprintf(`Config validation failure {"controller": "Api", "failures": %s}`, string(failuresJson)))
-			}
 			r.Log.Info("ConfigMap reconciliation finished")
 			return doneReconcileNoRequeue()
 		}
@@ -150,15 +164,22 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	r.Log.Info("Starting ApiRule reconciliation", "jwtHandler", r.Config.JWTHandler)
 
 	c := processing.ReconciliationConfig{
-		OathkeeperSvc:       r.OathkeeperSvc,
-		OathkeeperSvcPort:   r.OathkeeperSvcPort,
-		CorsConfig:          r.CorsConfig,
-		AdditionalLabels:    r.GeneratedObjectsLabels,
-		DefaultDomainName:   r.DefaultDomainName,
-		ServiceBlockList:    r.ServiceBlockList,
-		DomainAllowList:     r.DomainAllowList,
-		HostBlockList:       r.HostBlockList,
-		HTTPTimeoutDuration: helpers.DEFAULT_HTTP_TIMEOUT,
+		OathkeeperSvc:         r.OathkeeperSvc,
+		OathkeeperSvcPort:     r.OathkeeperSvcPort,
+		CorsConfig:            r.CorsConfig,
+		AdditionalLabels:      r.GeneratedObjectsLabels,
+		DefaultDomainName:     r.DefaultDomainName,
+		ServiceBlockList:      r.ServiceBlockList,
+		DomainAllowList:       r.DomainAllowList,
+		HostBlockList:         r.HostBlockList,
+		HTTPTimeoutDuration:   helpers.DEFAULT_HTTP_TIMEOUT,
+		ScopeClaims:           r.Config.ScopeClaims,
+		FaultInjectionEnabled: r.Config.FaultInjectionEnabled,
+		NetworkPolicyEnabled:  r.Config.NetworkPolicyEnabled,
+		Quota:                 r.Config.Quota,
+		Exposure:              r.Config.Exposure,
+		MaxRetryAttempts:      r.Config.MaxRetryAttempts,
+		AccessLogProvider:     r.Config.AccessLogProvider,
 	}
 
 	cmd := r.getReconciliation(c)
@@ -184,15 +205,21 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	if apiRule.DeletionTimestamp.IsZero() {
 		if !controllerutil.ContainsFinalizer(apiRule, API_GATEWAY_FINALIZER) {
-			controllerutil.AddFinalizer(apiRule, API_GATEWAY_FINALIZER)
-			if err := r.Update(ctx, apiRule); err != nil {
+			if err := r.updateWithRetryOnConflict(ctx, apiRule, func(api *gatewayv1beta1.APIRule) {
+				controllerutil.AddFinalizer(api, API_GATEWAY_FINALIZER)
+			}); err != nil {
 				return doneReconcileErrorRequeue(r.OnErrorReconcilePeriod)
 			}
 		}
 	} else {
 		if controllerutil.ContainsFinalizer(apiRule, API_GATEWAY_FINALIZER) {
 			// finalizer is present on APIRule, so all subresources need to be deleted
-			if err := processing.DeleteAPIRuleSubresources(r.Client, ctx, *apiRule); err != nil {
+			err := processing.DeleteAPIRuleSubresources(r.Client, r.APIReader, ctx, *apiRule)
+			if errors.Is(err, processing.ErrSubresourcesPending) {
+				r.Log.Info("Waiting for deletion of APIRule subresources", "apiRule", req.NamespacedName)
+				return doneReconcileErrorRequeue(r.OnErrorReconcilePeriod)
+			}
+			if err != nil {
 				r.Log.Error(err, "Error happened during deletion of APIRule subresources")
 				// if removing subresources ends in error, return with retry
 				// so that it can be retried
@@ -200,8 +227,10 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			}
 
 			// remove finalizer so the reconcilation can proceed
-			controllerutil.RemoveFinalizer(apiRule, API_GATEWAY_FINALIZER)
-			if err := r.Update(ctx, apiRule); err != nil {
+			err = r.updateWithRetryOnConflict(ctx, apiRule, func(api *gatewayv1beta1.APIRule) {
+				controllerutil.RemoveFinalizer(api, API_GATEWAY_FINALIZER)
+			})
+			if client.IgnoreNotFound(err) != nil {
 				r.Log.Error(err, "Error happened during finalizer removal")
 				return doneReconcileErrorRequeue(r.OnErrorReconcilePeriod)
 			}
@@ -209,6 +238,13 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return doneReconcileNoRequeue()
 	}
 
+	if apiRule.Annotations[SUSPEND_ANNOTATION] == "true" {
+		r.Log.Info("Skipping reconciliation of suspended ApiRule", "name", apiRule.Name, "namespace", apiRule.Namespace)
+		status := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
+		status.ApiRuleStatus.Description = fmt.Sprintf("Reconciliation is suspended by the %s annotation", SUSPEND_ANNOTATION)
+		return r.updateStatusOrRetry(ctx, apiRule, status)
+	}
+
 	r.Log.Info("Validating ApiRule config")
 	configValidationFailures := validator.ValidateConfig(r.Config)
 	if len(configValidationFailures) > 0 {
@@ -218,10 +254,70 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return r.updateStatusOrRetry(ctx, apiRule, processing.GenerateStatusFromFailures(configValidationFailures, statusBase))
 	}
 
-	status := processing.Reconcile(ctx, r.Client, &r.Log, cmd, apiRule)
+	// The normalized paths and methods are persisted, so that the APIRule shows what is enforced
+	if processing.NormalizeRules(apiRule.DeepCopy(), r.Config.TrailingSlashPolicy) {
+		if err := r.updateWithRetryOnConflict(ctx, apiRule, func(api *gatewayv1beta1.APIRule) {
+			processing.NormalizeRules(api, r.Config.TrailingSlashPolicy)
+		}); err != nil {
+			r.Log.Error(err, "Error updating ApiRule with normalized rules")
+			return doneReconcileErrorRequeue(r.OnErrorReconcilePeriod)
+		}
+	}
+
+	// The subresources are reconciled from a copy of the APIRule with the presets and trailing slashes expanded, the
+	// status is still written to the APIRule.
+	expandedAPIRule, presetFailures, err := processing.ExpandPresets(apiRule, r.Config.AccessStrategyPresets)
+	if err != nil {
+		r.Log.Error(err, "Error during expansion of access strategy presets")
+		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
+		errorMap := map[processing.ResourceSelector][]error{processing.OnApiRule: {err}}
+		return r.updateStatusOrRetry(ctx, apiRule, processing.GetStatusForErrorMap(errorMap, statusBase))
+	}
+	if len(presetFailures) > 0 {
+		failuresJson, _ := json.Marshal(presetFailures)
+		r.Log.Info(fmt.Sprintf(`Validation failure {"controller": "Api", "request": "%s/%s", "failures": %s}`, apiRule.Namespace, apiRule.Name, string(failuresJson)))
+		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
+		return r.updateStatusOrRetry(ctx, apiRule, processing.GenerateStatusFromFailures(presetFailures, statusBase))
+	}
+
+	expandedAPIRule = processing.ExpandTrailingSlash(expandedAPIRule, r.Config.TrailingSlashPolicy)
+
+	status := processing.Reconcile(ctx, r.Client, r.APIReader, &r.Log, cmd, expandedAPIRule)
 	return r.updateStatusOrRetry(ctx, apiRule, status)
 }
 
+// loadConfig reads the config from the ConfigMap and validates it. An invalid config is only applied if there is no valid
+// config yet, so that the APIRules keep being reconciled with the last valid config. The failures of a rejected config
+// are kept until the next load and shown in the status of the APIRules.
+func (r *APIRuleReconciler) loadConfig(ctx context.Context, validator *validation.APIRuleValidator) {
+	config := &helpers.Config{}
+	err := config.ReadFromConfigMap(ctx, r.Client)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			r.Log.Info(fmt.Sprintf(`ConfigMap %s in namespace %s was not found {"controller": "Api"}, will use default config`, helpers.CM_NAME, helpers.CM_NS))
+			config.ResetToDefault()
+		} else {
+			r.Log.Error(err, fmt.Sprintf(`could not read ConfigMap %s in namespace %s {"controller": "Api"}`, helpers.CM_NAME, helpers.CM_NS))
+			config.Reset()
+		}
+	}
+
+	r.Log.Info("ConfigMap loaded", "config", config)
+	configValidationFailures := validator.ValidateConfig(config)
+	if len(configValidationFailures) > 0 {
+		failuresJson, _ := json.Marshal(configValidationFailures)
+		r.Log.Error(err, fmt.Sprintf(`Config validation failure {"controller": "Api", "failures": %s}`, string(failuresJson)))
+	}
+
+	r.rejectedConfigFailures = nil
+	if len(configValidationFailures) == 0 || len(validator.ValidateConfig(r.Config)) > 0 {
+		r.Config = config
+	} else {
+		r.Log.Info("Keeping the last valid config", "config", r.Config)
+		r.rejectedConfigFailures = configValidationFailures
+	}
+}
+
 func (r *APIRuleReconciler) getReconciliation(config processing.ReconciliationConfig) processing.ReconciliationCommand {
 	if r.Config.JWTHandler == helpers.JWT_HANDLER_ISTIO {
 		return istio.NewIstioReconciliation(config, &r.Log)
@@ -234,13 +330,52 @@ func (r *APIRuleReconciler) getReconciliation(config processing.ReconciliationCo
 func (r *APIRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		// We need to filter for generation changes, because we had an issue that on Azure clusters the APIRules were constantly reconciled.
-		For(&gatewayv1beta1.APIRule{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(&isApiGatewayConfigMapPredicate{Log: r.Log})).
+		For(&gatewayv1beta1.APIRule{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, suspendAnnotationChangedPredicate{}))).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.configMapRequests), builder.WithPredicates(&isApiGatewayConfigMapPredicate{Log: r.Log})).
+		// A panic of the validation, a processor or applying the changes results in an error status of the APIRule. Other
+		// panics during the reconciliation of a single APIRule are returned as reconcile error, so that they don't crash the controller.
+		WithOptions(controller.Options{RecoverPanic: pointer.Bool(true)}).
 		Complete(r)
 }
 
+// configMapRequests returns the request for the reconciliation of the changed ConfigMap, followed by the requests for all
+// APIRules, so that they are reconciled with the new config.
+func (r *APIRuleReconciler) configMapRequests(cm client.Object) []reconcile.Request {
+	requests := []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(cm)}}
+
+	var apiRules gatewayv1beta1.APIRuleList
+	if err := r.Client.List(context.Background(), &apiRules); err != nil {
+		r.Log.Error(err, "Error listing APIRules for reconciliation after ConfigMap change")
+		return requests
+	}
+
+	for _, apiRule := range apiRules.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&apiRule)})
+	}
+
+	return requests
+}
+
+// updateWithRetryOnConflict applies the mutation to the APIRule and updates it. If the APIRule was modified in the meantime,
+// e.g. by another controller, the latest version is fetched and the mutation is applied again.
+func (r *APIRuleReconciler) updateWithRetryOnConflict(ctx context.Context, api *gatewayv1beta1.APIRule, mutate func(*gatewayv1beta1.APIRule)) error {
+	refetch := false
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if refetch {
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(api), api); err != nil {
+				return err
+			}
+		}
+		refetch = true
+
+		mutate(api)
+		return r.Update(ctx, api)
+	})
+}
+
 // Updates api status. If there was an error during update, returns the error so that entire reconcile loop is retried. If there is no error, returns a "reconcile success" value.
 func (r *APIRuleReconciler) updateStatusOrRetry(ctx context.Context, api *gatewayv1beta1.APIRule, status processing.ReconciliationStatus) (ctrl.Result, error) {
+	status = processing.WithRejectedConfigWarning(status, r.rejectedConfigFailures)
 	_, updateStatusErr := r.updateStatus(ctx, api, status)
 	if updateStatusErr != nil {
 		r.Log.Error(updateStatusErr, "Error updating ApiRule status, retrying")
@@ -347,10 +347,12 @@ func (jr *JwtRuleBuilder) From(val []*gatewayv1beta1.Authenticator) *JwtRuleBuil
 		if accessStrategy.Config != nil {
 			_ = json.Unmarshal(accessStrategy.Config.Raw, authentications)
 		}
+		audiences := authentications.requiredAudiences()
 		for _, authentication := range authentications.Authentications {
 			jwtRule := v1beta1.JWTRule{
-				Issuer:  authentication.Issuer,
-				JwksUri: authentication.JwksUri,
+				Issuer:    authentication.Issuer,
+				JwksUri:   authentication.JwksUri,
+				Audiences: audiences,
 			}
 			for _, fromHeader := range authentication.FromHeaders {
 				jwtRule.FromHeaders = append(jwtRule.FromHeaders, &v1beta1.JWTHeader{
@@ -392,6 +394,32 @@ func (s *SelectorBuilder) WithMatchLabels(key, val string) *SelectorBuilder {
 
 type Authentications struct {
 	Authentications []*Authentication `json:"authentications"`
+	Authorizations  []*Authorization  `json:"authorizations"`
+}
+
+// requiredAudiences returns the audiences that every token accepted by the access strategy must carry.
+// The audiences can only be enforced on the JWT layer if each of the authorizations restricts them, otherwise a token
+// with an audience that is not listed would be rejected by the RequestAuthentication although an authorization allows it.
+func (a *Authentications) requiredAudiences() []string {
+	if len(a.Authorizations) == 0 {
+		return nil
+	}
+
+	var audiences []string
+	seen := make(map[string]bool)
+	for _, authorization := range a.Authorizations {
+		if authorization == nil || len(authorization.Audiences) == 0 {
+			return nil
+		}
+		for _, audience := range authorization.Audiences {
+			if !seen[audience] {
+				seen[audience] = true
+				audiences = append(audiences, audience)
+			}
+		}
+	}
+
+	return audiences
 }
 
 type Authentication struct {
@@ -406,6 +434,10 @@ type JwtHeader struct {
 	Prefix string `json:"prefix"`
 }
 
+type Authorization struct {
+	Audiences []string `json:"audiences"`
+}
+
 func SelectorFromService(service *gatewayv1beta1.Service) *apiv1beta1.WorkloadSelector {
 	return &apiv1beta1.WorkloadSelector{
 		MatchLabels: map[string]string{authorizationPolicyAppSelectorLabel: *service.Name},
@@ -156,5 +156,39 @@ var _ = Describe("Builder for", func() {
 			Expect(ap.Spec.JwtRules[0].FromParams[1]).To(Equal("param2"))
 			Expect(ap.Spec.JwtRules[0].FromHeaders).To(BeEmpty())
 		})
+
+		It("should build an RequestAuthentication with audiences when all authorizations restrict audiences", func() {
+			testRaw := runtime.RawExtension{Raw: []byte(`{"authentications": [{"issuer": "testIssuer", "jwksUri": "testJwksUri"}], "authorizations": [{"audiences": ["aud1", "aud2"]}, {"requiredScopes": ["scope"], "audiences": ["aud2", "aud3"]}]}`)}
+			testHandler := gatewayv1beta1.Handler{Config: &testRaw}
+			testAuthenticator := gatewayv1beta1.Authenticator{Handler: &testHandler}
+			testAccessStrategies := []*gatewayv1beta1.Authenticator{&testAuthenticator}
+
+			ap := NewRequestAuthenticationBuilder().WithGenerateName(name).WithNamespace(namespace).
+				WithSpec(NewRequestAuthenticationSpecBuilder().
+					WithSelector(NewSelectorBuilder().WithMatchLabels(testMatchLabelsKey, testMatchLabelsValue).Get()).
+					WithJwtRules(*NewJwtRuleBuilder().From(testAccessStrategies).Get()).
+					Get()).
+				Get()
+
+			Expect(ap.Spec.JwtRules).To(HaveLen(1))
+			Expect(ap.Spec.JwtRules[0].Audiences).To(Equal([]string{"aud1", "aud2", "aud3"}))
+		})
+
+		It("should build an RequestAuthentication without audiences when an authorization does not restrict audiences", func() {
+			testRaw := runtime.RawExtension{Raw: []byte(`{"authentications": [{"issuer": "testIssuer", "jwksUri": "testJwksUri"}], "authorizations": [{"audiences": ["aud1"]}, {"requiredScopes": ["scope"]}]}`)}
+			testHandler := gatewayv1beta1.Handler{Config: &testRaw}
+			testAuthenticator := gatewayv1beta1.Authenticator{Handler: &testHandler}
+			testAccessStrategies := []*gatewayv1beta1.Authenticator{&testAuthenticator}
+
+			ap := NewRequestAuthenticationBuilder().WithGenerateName(name).WithNamespace(namespace).
+				WithSpec(NewRequestAuthenticationSpecBuilder().
+					WithSelector(NewSelectorBuilder().WithMatchLabels(testMatchLabelsKey, testMatchLabelsValue).Get()).
+					WithJwtRules(*NewJwtRuleBuilder().From(testAccessStrategies).Get()).
+					Get()).
+				Get()
+
+			Expect(ap.Spec.JwtRules).To(HaveLen(1))
+			Expect(ap.Spec.JwtRules[0].Audiences).To(BeEmpty())
+		})
 	})
 })
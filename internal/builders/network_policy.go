@@ -0,0 +1,73 @@
+package builders
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// namespaceNameLabel is set by Kubernetes on every namespace to the name of the namespace
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// NetworkPolicy returns builder for k8s.io/api/networking/v1/NetworkPolicy type
+func NetworkPolicy() *networkPolicy {
+	return &networkPolicy{
+		value: &networkingv1.NetworkPolicy{},
+	}
+}
+
+type networkPolicy struct {
+	value *networkingv1.NetworkPolicy
+}
+
+func (np *networkPolicy) Get() *networkingv1.NetworkPolicy {
+	return np.value
+}
+
+func (np *networkPolicy) GenerateName(val string) *networkPolicy {
+	np.value.Name = ""
+	np.value.GenerateName = val
+	return np
+}
+
+func (np *networkPolicy) Namespace(val string) *networkPolicy {
+	np.value.Namespace = val
+	return np
+}
+
+func (np *networkPolicy) Label(key, val string) *networkPolicy {
+	if np.value.Labels == nil {
+		np.value.Labels = make(map[string]string)
+	}
+	np.value.Labels[key] = val
+	return np
+}
+
+// PodSelector sets the pods the NetworkPolicy applies to and restricts their ingress traffic.
+func (np *networkPolicy) PodSelector(matchLabels map[string]string) *networkPolicy {
+	np.value.Spec.PodSelector = metav1.LabelSelector{MatchLabels: matchLabels}
+	np.value.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	return np
+}
+
+// AllowFromNamespacePods allows ingress traffic from the pods with given labels in the namespace with the given name.
+func (np *networkPolicy) AllowFromNamespacePods(namespace string, podLabels map[string]string) *networkPolicy {
+	return np.allowFrom(networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: namespace}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: podLabels},
+	})
+}
+
+// AllowFromSameNamespace allows ingress traffic from all pods in the namespace of the NetworkPolicy.
+func (np *networkPolicy) AllowFromSameNamespace() *networkPolicy {
+	return np.allowFrom(networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{},
+	})
+}
+
+func (np *networkPolicy) allowFrom(peer networkingv1.NetworkPolicyPeer) *networkPolicy {
+	if len(np.value.Spec.Ingress) == 0 {
+		np.value.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{}}
+	}
+	np.value.Spec.Ingress[0].From = append(np.value.Spec.Ingress[0].From, peer)
+	return np
+}
@@ -0,0 +1,77 @@
+package builders
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	telemetryapiv1alpha1 "istio.io/api/telemetry/v1alpha1"
+	apiv1beta1 "istio.io/api/type/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+)
+
+// Telemetry returns builder for istio.io/client-go/pkg/apis/telemetry/v1alpha1/Telemetry type
+func Telemetry() *telemetry {
+	return &telemetry{
+		value: &telemetryv1alpha1.Telemetry{},
+	}
+}
+
+type telemetry struct {
+	value *telemetryv1alpha1.Telemetry
+}
+
+func (t *telemetry) Get() *telemetryv1alpha1.Telemetry {
+	return t.value
+}
+
+func (t *telemetry) GenerateName(val string) *telemetry {
+	t.value.Name = ""
+	t.value.GenerateName = val
+	return t
+}
+
+func (t *telemetry) Namespace(val string) *telemetry {
+	t.value.Namespace = val
+	return t
+}
+
+func (t *telemetry) Label(key, val string) *telemetry {
+	if t.value.Labels == nil {
+		t.value.Labels = make(map[string]string)
+	}
+	t.value.Labels[key] = val
+	return t
+}
+
+func (t *telemetry) Selector(val *apiv1beta1.WorkloadSelector) *telemetry {
+	t.value.Spec.Selector = val
+	return t
+}
+
+// Tracing sets the sampling percentage and the literal custom tags of the traces of the selected workloads.
+func (t *telemetry) Tracing(tracing *gatewayv1beta1.Tracing) *telemetry {
+	value := &telemetryapiv1alpha1.Tracing{
+		RandomSamplingPercentage: wrapperspb.Double(float64(tracing.SamplingRate)),
+	}
+	for name, tag := range tracing.CustomTags {
+		if value.CustomTags == nil {
+			value.CustomTags = make(map[string]*telemetryapiv1alpha1.Tracing_CustomTag)
+		}
+		value.CustomTags[name] = &telemetryapiv1alpha1.Tracing_CustomTag{
+			Type: &telemetryapiv1alpha1.Tracing_CustomTag_Literal{
+				Literal: &telemetryapiv1alpha1.Tracing_Literal{Value: tag},
+			},
+		}
+	}
+	t.value.Spec.Tracing = []*telemetryapiv1alpha1.Tracing{value}
+	return t
+}
+
+// AccessLogging enables the access logs of the selected workloads, written by the extension provider with the given name.
+func (t *telemetry) AccessLogging(provider string) *telemetry {
+	t.value.Spec.AccessLogging = []*telemetryapiv1alpha1.AccessLogging{
+		{
+			Providers: []*telemetryapiv1alpha1.ProviderRef{{Name: provider}},
+		},
+	}
+	return t
+}
@@ -1,7 +1,9 @@
 package builders
 
 import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/api/networking/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"time"
@@ -131,6 +133,16 @@ func (hr *httpRoute) Timeout(value time.Duration) *httpRoute {
 	return hr
 }
 
+func (hr *httpRoute) Retries(r *httpRetry) *httpRoute {
+	hr.value.Retries = r.Get()
+	return hr
+}
+
+func (hr *httpRoute) Fault(fi *faultInjection) *httpRoute {
+	hr.value.Fault = fi.Get()
+	return hr
+}
+
 // MatchRequest returns builder for istio.io/api/networking/v1beta1/HTTPMatchRequest type
 func MatchRequest() *matchRequest {
 	return &matchRequest{
@@ -194,6 +206,88 @@ func (rd *routeDestination) Port(val uint32) *routeDestination {
 	return rd
 }
 
+// FaultInjection returns builder for istio.io/api/networking/v1beta1/HTTPFaultInjection type
+func FaultInjection() *faultInjection {
+	return &faultInjection{
+		value: &v1beta1.HTTPFaultInjection{},
+	}
+}
+
+type faultInjection struct {
+	value *v1beta1.HTTPFaultInjection
+}
+
+func (fi *faultInjection) Get() *v1beta1.HTTPFaultInjection {
+	return fi.value
+}
+
+func (fi *faultInjection) Delay(fixedDelay time.Duration, percentage float64) *faultInjection {
+	fi.value.Delay = &v1beta1.HTTPFaultInjection_Delay{
+		HttpDelayType: &v1beta1.HTTPFaultInjection_Delay_FixedDelay{FixedDelay: durationpb.New(fixedDelay)},
+		Percentage:    &v1beta1.Percent{Value: percentage},
+	}
+	return fi
+}
+
+func (fi *faultInjection) Abort(httpStatus int32, percentage float64) *faultInjection {
+	fi.value.Abort = &v1beta1.HTTPFaultInjection_Abort{
+		ErrorType:  &v1beta1.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: httpStatus},
+		Percentage: &v1beta1.Percent{Value: percentage},
+	}
+	return fi
+}
+
+func (fi *faultInjection) From(fault *gatewayv1beta1.Fault) *faultInjection {
+	if fault.Delay != nil {
+		fi.Delay(fault.Delay.FixedDelay.Duration, float64(fault.Delay.Percentage))
+	}
+	if fault.Abort != nil {
+		fi.Abort(fault.Abort.HttpStatus, float64(fault.Abort.Percentage))
+	}
+	return fi
+}
+
+// HTTPRetry returns builder for istio.io/api/networking/v1beta1/HTTPRetry type
+func HTTPRetry() *httpRetry {
+	return &httpRetry{
+		value: &v1beta1.HTTPRetry{},
+	}
+}
+
+type httpRetry struct {
+	value *v1beta1.HTTPRetry
+}
+
+func (hr *httpRetry) Get() *v1beta1.HTTPRetry {
+	return hr.value
+}
+
+func (hr *httpRetry) Attempts(val int32) *httpRetry {
+	hr.value.Attempts = val
+	return hr
+}
+
+func (hr *httpRetry) PerTryTimeout(val time.Duration) *httpRetry {
+	hr.value.PerTryTimeout = durationpb.New(val)
+	return hr
+}
+
+func (hr *httpRetry) RetryOn(val string) *httpRetry {
+	hr.value.RetryOn = val
+	return hr
+}
+
+func (hr *httpRetry) From(retries *gatewayv1beta1.Retries) *httpRetry {
+	hr.Attempts(retries.Attempts)
+	if retries.PerTryTimeout != nil {
+		hr.PerTryTimeout(retries.PerTryTimeout.Duration)
+	}
+	if retries.RetryOn != "" {
+		hr.RetryOn(retries.RetryOn)
+	}
+	return hr
+}
+
 // CorsPolicy returns builder for istio.io/api/networking/v1beta1/CorsPolicy type
 func CorsPolicy() *corsPolicy {
 	return &corsPolicy{
@@ -236,6 +330,58 @@ func (cp *corsPolicy) AllowOrigins(val ...*v1beta1.StringMatch) *corsPolicy {
 	return cp
 }
 
+func (cp *corsPolicy) ExposeHeaders(val ...string) *corsPolicy {
+	if len(val) == 0 {
+		cp.value.ExposeHeaders = nil
+	} else {
+		cp.value.ExposeHeaders = append(cp.value.ExposeHeaders, val...)
+	}
+	return cp
+}
+
+func (cp *corsPolicy) AllowCredentials(val bool) *corsPolicy {
+	cp.value.AllowCredentials = wrapperspb.Bool(val)
+	return cp
+}
+
+func (cp *corsPolicy) MaxAge(val time.Duration) *corsPolicy {
+	cp.value.MaxAge = durationpb.New(val)
+	return cp
+}
+
+// From sets the CORS policy defined in an APIRule.
+func (cp *corsPolicy) From(policy *gatewayv1beta1.CorsPolicy) *corsPolicy {
+	var origins []*v1beta1.StringMatch
+	for _, origin := range policy.AllowOrigins {
+		origins = append(origins, stringMatchFrom(origin))
+	}
+
+	cp.AllowOrigins(origins...).
+		AllowMethods(policy.AllowMethods...).
+		AllowHeaders(policy.AllowHeaders...).
+		ExposeHeaders(policy.ExposeHeaders...)
+
+	if policy.AllowCredentials != nil {
+		cp.AllowCredentials(*policy.AllowCredentials)
+	}
+	if policy.MaxAge != nil {
+		cp.MaxAge(policy.MaxAge.Duration)
+	}
+	return cp
+}
+
+// stringMatchFrom returns the istio.io/api/networking/v1beta1/StringMatch for a StringMatch of an APIRule.
+func stringMatchFrom(match gatewayv1beta1.StringMatch) *v1beta1.StringMatch {
+	switch {
+	case match.Exact != "":
+		return &v1beta1.StringMatch{MatchType: &v1beta1.StringMatch_Exact{Exact: match.Exact}}
+	case match.Prefix != "":
+		return &v1beta1.StringMatch{MatchType: &v1beta1.StringMatch_Prefix{Prefix: match.Prefix}}
+	default:
+		return &v1beta1.StringMatch{MatchType: &v1beta1.StringMatch_Regex{Regex: match.Regex}}
+	}
+}
+
 // NewHttpRouteHeadersBuilder returns builder for istio.io/api/networking/v1beta1/Headers type
 func NewHttpRouteHeadersBuilder() HttpRouteHeadersBuilder {
 	return HttpRouteHeadersBuilder{
@@ -1,11 +1,13 @@
 package builders
 
 import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/helpers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"google.golang.org/protobuf/types/known/durationpb"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"time"
 )
 
@@ -106,4 +108,37 @@ var _ = Describe("Builder for", func() {
 			Expect(result.Http[1].Route[0].Weight).To(Equal(int32(100)))
 		})
 	})
+
+	Context("CorsPolicy", func() {
+		It("should build the CORS policy from the APIRule", func() {
+			allowCredentials := false
+			policy := &gatewayv1beta1.CorsPolicy{
+				AllowOrigins:     []gatewayv1beta1.StringMatch{{Exact: "https://example.com"}, {Prefix: "https://app."}},
+				AllowMethods:     []string{"GET"},
+				AllowHeaders:     []string{"Authorization"},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: &allowCredentials,
+				MaxAge:           &metav1.Duration{Duration: time.Minute},
+			}
+
+			result := CorsPolicy().From(policy).Get()
+
+			Expect(result.AllowOrigins).To(HaveLen(2))
+			Expect(result.AllowOrigins[0].GetExact()).To(Equal("https://example.com"))
+			Expect(result.AllowOrigins[1].GetPrefix()).To(Equal("https://app."))
+			Expect(result.AllowMethods).To(Equal([]string{"GET"}))
+			Expect(result.AllowHeaders).To(Equal([]string{"Authorization"}))
+			Expect(result.ExposeHeaders).To(Equal([]string{"X-Request-Id"}))
+			Expect(result.AllowCredentials.GetValue()).To(BeFalse())
+			Expect(result.MaxAge.AsDuration()).To(Equal(time.Minute))
+		})
+
+		It("should not set optional fields that are not defined in the APIRule", func() {
+			result := CorsPolicy().From(&gatewayv1beta1.CorsPolicy{}).Get()
+
+			Expect(result.AllowOrigins).To(BeNil())
+			Expect(result.AllowCredentials).To(BeNil())
+			Expect(result.MaxAge).To(BeNil())
+		})
+	})
 })
@@ -12,6 +12,15 @@ const (
 	JWT_HANDLER_ORY      = "ory"
 	JWT_HANDLER_ISTIO    = "istio"
 	DEFAULT_HTTP_TIMEOUT = 180
+	// DEFAULT_ACCESS_LOG_PROVIDER is the access log provider that Istio defines by default
+	DEFAULT_ACCESS_LOG_PROVIDER = "envoy"
+
+	// TRAILING_SLASH_KEEP keeps the trailing slash of rule paths, so that "/orders" and "/orders/" are different paths
+	TRAILING_SLASH_KEEP = "keep"
+	// TRAILING_SLASH_STRIP removes the trailing slash of rule paths
+	TRAILING_SLASH_STRIP = "strip"
+	// TRAILING_SLASH_BOTH removes the trailing slash of rule paths and matches them with and without it
+	TRAILING_SLASH_BOTH = "both"
 
 	CM_NS   = "kyma-system"
 	CM_NAME = "api-gateway-config"
@@ -31,14 +40,90 @@ var ReadConfigMapHandle = ReadConfigMap
 
 type Config struct {
 	JWTHandler string `yaml:"jwtHandler"`
+	// ScopeClaims are the names of the JWT claims that are checked for the required scopes by the Istio JWT handler.
+	// Identity providers differ in the claim they use, so all default claims are checked if nothing is configured.
+	ScopeClaims []string `yaml:"scopeClaims,omitempty"`
+	// FaultInjectionEnabled allows APIRules to inject faults into the traffic of their rules. It is disabled by default,
+	// so that faults can't be injected on production clusters.
+	FaultInjectionEnabled bool `yaml:"faultInjectionEnabled,omitempty"`
+	// NetworkPolicyEnabled creates NetworkPolicies that allow ingress traffic to the exposed workloads only from the
+	// ingress gateway, Oathkeeper and the namespace of the workload, so that the access strategies can't be bypassed.
+	NetworkPolicyEnabled bool `yaml:"networkPolicyEnabled,omitempty"`
+	// Quota limits the number of APIRules and rules that can be exposed.
+	Quota QuotaConfig `yaml:"quota,omitempty"`
+	// Exposure restricts which services can be exposed by APIRules, in addition to the service blocklist of the controller.
+	Exposure ExposureConfig `yaml:"exposure,omitempty"`
+	// MaxRetryAttempts is the maximum number of retries an APIRule can configure for its requests. A limit of 0 means
+	// that there is no limit.
+	MaxRetryAttempts int32 `yaml:"maxRetryAttempts,omitempty"`
+	// AccessLogProvider is the Istio extension provider that writes the access logs of APIRules that don't define a
+	// provider. The default provider of Istio is used if nothing is configured.
+	AccessLogProvider string `yaml:"accessLogProvider,omitempty"`
+	// AccessStrategyPresets are named lists of access strategies. An access strategy with the preset handler is replaced by
+	// the access strategies of the preset named in its config, so that APIRules don't have to repeat the same config.
+	AccessStrategyPresets map[string][]AccessStrategyPreset `yaml:"accessStrategyPresets,omitempty"`
+	// TrailingSlashPolicy defines how the trailing slash of rule paths is handled: keep, strip, or both. The paths are kept
+	// as they are if nothing is configured.
+	TrailingSlashPolicy string `yaml:"trailingSlashPolicy,omitempty"`
+}
+
+// AccessStrategyPreset is an access strategy of a preset.
+type AccessStrategyPreset struct {
+	Handler string `yaml:"handler"`
+	// Config configures the handler like the config of an access strategy in an APIRule.
+	Config interface{} `yaml:"config,omitempty"`
+}
+
+// ExposureConfig contains the policy for the services exposed by APIRules. An empty list means that there is no restriction.
+type ExposureConfig struct {
+	// BlockedNamespaces are the namespaces in which no service can be exposed.
+	BlockedNamespaces []string `yaml:"blockedNamespaces,omitempty"`
+	// BlockedServices are the names of the services that can't be exposed in any namespace.
+	BlockedServices []string `yaml:"blockedServices,omitempty"`
+	// AllowedHostSuffixes are the only domain suffixes of the hosts exposed by APIRules, e.g. "example.com" allows
+	// "httpbin.example.com".
+	AllowedHostSuffixes []string `yaml:"allowedHostSuffixes,omitempty"`
+	// AllowedPorts are the only service ports that can be exposed.
+	AllowedPorts []uint32 `yaml:"allowedPorts,omitempty"`
+	// RestrictCrossNamespaceTargets denies exposing services in another namespace than the namespace of the APIRule,
+	// unless the namespace of the service is in AllowedTargetNamespaces.
+	RestrictCrossNamespaceTargets bool `yaml:"restrictCrossNamespaceTargets,omitempty"`
+	// AllowedTargetNamespaces are the namespaces whose services can be exposed by APIRules in other namespaces.
+	AllowedTargetNamespaces []string `yaml:"allowedTargetNamespaces,omitempty"`
+}
+
+// QuotaConfig contains the limits for the exposure of APIs. A limit of 0 means that there is no limit.
+type QuotaConfig struct {
+	// MaxAPIRulesPerNamespace is the maximum number of exposed APIRules, and therefore hosts, in a namespace.
+	MaxAPIRulesPerNamespace int `yaml:"maxAPIRulesPerNamespace,omitempty"`
+	// MaxRulesPerAPIRule is the maximum number of rules of an APIRule.
+	MaxRulesPerAPIRule int `yaml:"maxRulesPerAPIRule,omitempty"`
 }
 
 func (c *Config) Reset() {
 	c.JWTHandler = ""
+	c.ScopeClaims = nil
+	c.FaultInjectionEnabled = false
+	c.NetworkPolicyEnabled = false
+	c.Quota = QuotaConfig{}
+	c.Exposure = ExposureConfig{}
+	c.MaxRetryAttempts = 0
+	c.AccessLogProvider = ""
+	c.AccessStrategyPresets = nil
+	c.TrailingSlashPolicy = ""
 }
 
 func (c *Config) ResetToDefault() {
 	c.JWTHandler = JWT_HANDLER_ORY
+	c.ScopeClaims = nil
+	c.FaultInjectionEnabled = false
+	c.NetworkPolicyEnabled = false
+	c.Quota = QuotaConfig{}
+	c.Exposure = ExposureConfig{}
+	c.MaxRetryAttempts = 0
+	c.AccessLogProvider = ""
+	c.AccessStrategyPresets = nil
+	c.TrailingSlashPolicy = ""
 }
 
 func (c *Config) ReadFromConfigMap(ctx context.Context, client client.Client) error {
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// QUOTA_MAX_APIRULES_ANNOTATION overrides quota.maxAPIRulesPerNamespace for the annotated namespace
+	QUOTA_MAX_APIRULES_ANNOTATION = "gateway.kyma-project.io/max-apirules"
+	// QUOTA_MAX_RULES_ANNOTATION overrides quota.maxRulesPerAPIRule for the APIRules in the annotated namespace
+	QUOTA_MAX_RULES_ANNOTATION = "gateway.kyma-project.io/max-rules-per-apirule"
+)
+
+// NamespaceQuota returns the quota for the APIRules in the namespace. The limits of the quota can be overridden with
+// annotations of the namespace, so that operators can grant single namespaces a different quota than the cluster.
+func NamespaceQuota(ctx context.Context, k8sClient client.Client, quota QuotaConfig, namespace string) (QuotaConfig, error) {
+	ns := &corev1.Namespace{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierrs.IsNotFound(err) {
+			return quota, nil
+		}
+		return quota, err
+	}
+
+	overrides := []struct {
+		annotation string
+		limit      *int
+	}{
+		{QUOTA_MAX_APIRULES_ANNOTATION, &quota.MaxAPIRulesPerNamespace},
+		{QUOTA_MAX_RULES_ANNOTATION, &quota.MaxRulesPerAPIRule},
+	}
+
+	for _, override := range overrides {
+		value, ok := ns.Annotations[override.annotation]
+		if !ok {
+			continue
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return quota, fmt.Errorf("value %q of annotation %s of namespace %s is not a valid limit", value, override.annotation, namespace)
+		}
+		*override.limit = limit
+	}
+
+	return quota, nil
+}
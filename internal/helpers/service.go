@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// statefulSetPodSubdomain matches the DNS name "<statefulset>-<index>.<service>" of a single pod of a StatefulSet
+// relative to the namespace.
+var statefulSetPodSubdomain = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?-[0-9]+)\.([a-z0-9]([-a-z0-9]*[a-z0-9])?)$`)
+
+// ServiceKey returns the key of the Service in the map returned by GetExposedServices.
+func ServiceKey(namespace string, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// IsHeadless returns true if the Service has no cluster IP, so that its DNS name resolves to the IPs of its pods.
+func IsHeadless(service *corev1.Service) bool {
+	return service.Spec.ClusterIP == corev1.ClusterIPNone
+}
+
+// StatefulSetPodSubdomain returns the pod and the Service if the service name is the DNS name of a single pod of a
+// StatefulSet, e.g. "web-0.nginx".
+func StatefulSetPodSubdomain(serviceName string) (pod string, service string, ok bool) {
+	match := statefulSetPodSubdomain.FindStringSubmatch(serviceName)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[3], true
+}
+
+// GetService returns the Service, or nil if it doesn't exist.
+func GetService(ctx context.Context, k8sClient client.Client, namespace string, name string) (*corev1.Service, error) {
+	service := &corev1.Service{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, service); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return service, nil
+}
+
+// GetExposedServices returns the existing Services exposed by the APIRule, keyed by ServiceKey. External services are
+// not read.
+func GetExposedServices(ctx context.Context, k8sClient client.Client, api *gatewayv1beta1.APIRule) (map[string]*corev1.Service, error) {
+	services := make(map[string]*corev1.Service)
+
+	for i := range api.Spec.Rules {
+		rule := &api.Spec.Rules[i]
+		service := api.Spec.Service
+		if rule.Service != nil {
+			service = rule.Service
+		}
+		if service == nil || service.Name == nil || (service.IsExternal != nil && *service.IsExternal) {
+			continue
+		}
+
+		namespace := FindServiceNamespace(api, rule)
+		key := ServiceKey(namespace, *service.Name)
+		if _, ok := services[key]; ok {
+			continue
+		}
+
+		s, err := GetService(ctx, k8sClient, namespace, *service.Name)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			services[key] = s
+		}
+	}
+
+	return services, nil
+}
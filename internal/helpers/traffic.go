@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"time"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+)
+
+// FindTimeout returns the timeout of the requests of the rule.
+func FindTimeout(api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule, defaultTimeout time.Duration) time.Duration {
+	// Fallback direction for the timeout: Rule.Timeout > Spec.Timeout > default timeout of the controller
+	if rule != nil && rule.Timeout != nil {
+		return rule.Timeout.Duration
+	}
+	if api != nil && api.Spec.Timeout != nil {
+		return api.Spec.Timeout.Duration
+	}
+	return defaultTimeout
+}
+
+// FindRetries returns the retries of the requests of the rule, or nil if requests are not retried.
+func FindRetries(api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule) *gatewayv1beta1.Retries {
+	// Fallback direction for the retries: Rule.Retries > Spec.Retries
+	if rule != nil && rule.Retries != nil {
+		return rule.Retries
+	}
+	if api != nil {
+		return api.Spec.Retries
+	}
+	return nil
+}
@@ -2,70 +2,71 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"reflect"
 
-	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
-	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
-	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func DeleteAPIRuleSubresources(k8sClient client.Client, ctx context.Context, apiRule gatewayv1beta1.APIRule) error {
+// ErrSubresourcesPending is returned if subresources of a class are not gone yet, so the deletion of the next classes
+// has to be retried later.
+var ErrSubresourcesPending = errors.New("deletion of APIRule subresources is still pending")
+
+// DeleteAPIRuleSubresources deletes the subresources of the APIRule class by class in subresourceDeletionOrder. The next class is only deleted when all
+// subresources of the previous class are gone, otherwise ErrSubresourcesPending is returned. The remaining subresources are
+// read with the apiReader, which must not be cached, since a cache still contains the subresources right after they are deleted.
+func DeleteAPIRuleSubresources(k8sClient client.Client, apiReader client.Reader, ctx context.Context, apiRule gatewayv1beta1.APIRule) error {
 	labels := GetOwnerLabels(&apiRule)
 
-	var apList securityv1beta1.AuthorizationPolicyList
-	err := k8sClient.List(ctx, &apList, client.MatchingLabels(labels))
-	if err != nil {
-		return err
-	}
-	for _, ap := range apList.Items {
-		log.Log.Info("Removing subresource", "AuthorizationPolicy", ap.Name)
-		err := k8sClient.Delete(ctx, ap)
-		if err != nil {
-			return err
+	for _, class := range subresourceDeletionOrder {
+		for _, s := range subresourcesOfClass(class) {
+			objects, err := listSubresources(k8sClient, ctx, s.newList(), labels)
+			if err != nil {
+				return err
+			}
+			for _, obj := range objects {
+				log.Log.Info("Removing subresource", reflect.TypeOf(obj).Elem().Name(), obj.GetName())
+				if err := k8sClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+					return err
+				}
+			}
 		}
-	}
 
-	var raList securityv1beta1.RequestAuthenticationList
-	err = k8sClient.List(ctx, &raList, client.MatchingLabels(labels))
-	if err != nil {
-		return err
-	}
-	for _, ra := range raList.Items {
-		log.Log.Info("Removing subresource", "RequestAuthentication", ra.Name)
-		err := k8sClient.Delete(ctx, ra)
-		if err != nil {
-			return err
+		for _, s := range subresourcesOfClass(class) {
+			remaining, err := listSubresources(apiReader, ctx, s.newList(), labels)
+			if err != nil {
+				return err
+			}
+			if len(remaining) > 0 {
+				return ErrSubresourcesPending
+			}
 		}
 	}
 
-	var vsList networkingv1beta1.VirtualServiceList
-	err = k8sClient.List(ctx, &vsList, client.MatchingLabels(labels))
-	if err != nil {
-		return err
-	}
-	for _, vs := range vsList.Items {
-		log.Log.Info("Removing subresource", "VirtualService", vs.Name)
-		err := k8sClient.Delete(ctx, vs)
-		if err != nil {
-			return err
-		}
+	return nil
+}
+
+// listSubresources returns the subresources with the labels. If the API of the subresources isn't available in the cluster,
+// e.g. because Istio Telemetry isn't installed, there are no subresources of this kind.
+func listSubresources(reader client.Reader, ctx context.Context, list client.ObjectList, labels map[string]string) ([]client.Object, error) {
+	if err := reader.List(ctx, list, client.MatchingLabels(labels)); meta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	var ruleList rulev1alpha1.RuleList
-	err = k8sClient.List(ctx, &ruleList, client.MatchingLabels(labels))
+	items, err := meta.ExtractList(list)
 	if err != nil {
-		return err
-	}
-	for _, accessRule := range ruleList.Items {
-		log.Log.Info("Removing subresource", "Rule", accessRule.Name)
-		err := k8sClient.Delete(ctx, &accessRule)
-		if err != nil {
-			return err
-		}
+		return nil, err
 	}
 
-	return nil
+	objects := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		objects = append(objects, item.(client.Object))
+	}
+	return objects, nil
 }
@@ -7,6 +7,7 @@ import (
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/kyma-project/api-gateway/internal/processing"
 	testUtils "github.com/kyma-project/api-gateway/internal/processing/internal/test"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("APIRule subresources deletion", func() {
@@ -84,10 +86,18 @@ var _ = Describe("APIRule subresources deletion", func() {
 			ObjectMeta: notApiRuleObjectMeta,
 		}
 
-		client := testUtils.GetFakeClient(&apiRuleVS, &otherVS, &apiRuleRule, &otherRule, &apiRuleAP, &otherAP, &apiRuleRA, &otherRA)
+		apiRuleNP := networkingv1.NetworkPolicy{
+			ObjectMeta: apiRuleObjectMeta,
+		}
+
+		otherNP := networkingv1.NetworkPolicy{
+			ObjectMeta: notApiRuleObjectMeta,
+		}
+
+		client := testUtils.GetFakeClient(&apiRuleVS, &otherVS, &apiRuleRule, &otherRule, &apiRuleAP, &otherAP, &apiRuleRA, &otherRA, &apiRuleNP, &otherNP)
 
 		// when
-		err := processing.DeleteAPIRuleSubresources(client, context.TODO(), *apiRule)
+		err := processing.DeleteAPIRuleSubresources(client, client, context.TODO(), *apiRule)
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// then
@@ -118,5 +128,128 @@ var _ = Describe("APIRule subresources deletion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(raList.Items).To(HaveLen(1))
 		Expect(raList.Items[0].Name).To(Equal("test-other-apirule"))
+
+		npList := networkingv1.NetworkPolicyList{}
+		err = client.List(context.TODO(), &npList)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(npList.Items).To(HaveLen(1))
+		Expect(npList.Items[0].Name).To(Equal("test-other-apirule"))
+	})
+
+	It("should delete subresources of the APIRule in the namespace of a service in another namespace", func() {
+		// given
+		apiRule := testUtils.GetAPIRuleFor([]gatewayv1beta1.Rule{})
+
+		targetNamespaceObjectMeta := func(owner string) v1.ObjectMeta {
+			return v1.ObjectMeta{
+				Name:      "test-apirule-target",
+				Namespace: "target-namespace",
+				Labels: map[string]string{
+					"apirule.gateway.kyma-project.io/v1alpha1": fmt.Sprintf("%s.%s", owner, apiRule.Namespace),
+				},
+			}
+		}
+
+		apiRuleAP := securityv1beta1.AuthorizationPolicy{ObjectMeta: targetNamespaceObjectMeta(apiRule.Name)}
+		apiRuleRA := securityv1beta1.RequestAuthentication{ObjectMeta: targetNamespaceObjectMeta(apiRule.Name)}
+		otherAP := securityv1beta1.AuthorizationPolicy{ObjectMeta: targetNamespaceObjectMeta("some-other")}
+		otherAP.Name = "test-other-apirule"
+
+		client := testUtils.GetFakeClient(&apiRuleAP, &apiRuleRA, &otherAP)
+
+		// when
+		err := processing.DeleteAPIRuleSubresources(client, client, context.TODO(), *apiRule)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		apList := securityv1beta1.AuthorizationPolicyList{}
+		Expect(client.List(context.TODO(), &apList)).To(Succeed())
+		Expect(apList.Items).To(HaveLen(1))
+		Expect(apList.Items[0].Name).To(Equal("test-other-apirule"))
+
+		raList := securityv1beta1.RequestAuthenticationList{}
+		Expect(client.List(context.TODO(), &raList)).To(Succeed())
+		Expect(raList.Items).To(BeEmpty())
+	})
+
+	It("should delete subresources when the Telemetry API is not available in the cluster", func() {
+		// given
+		apiRule := testUtils.GetAPIRuleFor([]gatewayv1beta1.Rule{})
+
+		apiRuleVS := networkingv1beta1.VirtualService{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "test-apirule-psdh34",
+				Namespace: testUtils.ApiNamespace,
+				Labels: map[string]string{
+					"apirule.gateway.kyma-project.io/v1alpha1": fmt.Sprintf("%s.%s", apiRule.Name, apiRule.Namespace),
+				},
+			},
+		}
+
+		client := testUtils.GetFakeClientWithoutTelemetryAPI(&apiRuleVS)
+
+		// when
+		err := processing.DeleteAPIRuleSubresources(client, client, context.TODO(), *apiRule)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		vsList := networkingv1beta1.VirtualServiceList{}
+		Expect(client.List(context.TODO(), &vsList)).To(Succeed())
+		Expect(vsList.Items).To(BeEmpty())
+	})
+
+	It("should not delete policies while the virtual services of the APIRule are not gone", func() {
+		// given
+		apiRule := testUtils.GetAPIRuleFor([]gatewayv1beta1.Rule{})
+
+		apiRuleObjectMeta := func(finalizers ...string) v1.ObjectMeta {
+			return v1.ObjectMeta{
+				Name:       "test-apirule-psdh34",
+				Namespace:  testUtils.ApiNamespace,
+				Finalizers: finalizers,
+				Labels: map[string]string{
+					"apirule.gateway.kyma-project.io/v1alpha1": fmt.Sprintf("%s.%s", apiRule.Name, apiRule.Namespace),
+				},
+			}
+		}
+
+		apiRuleVS := networkingv1beta1.VirtualService{ObjectMeta: apiRuleObjectMeta("test/finalizer")}
+		apiRuleAP := securityv1beta1.AuthorizationPolicy{ObjectMeta: apiRuleObjectMeta()}
+		apiRuleRA := securityv1beta1.RequestAuthentication{ObjectMeta: apiRuleObjectMeta()}
+
+		client := testUtils.GetFakeClient(&apiRuleVS, &apiRuleAP, &apiRuleRA)
+
+		// when
+		err := processing.DeleteAPIRuleSubresources(client, client, context.TODO(), *apiRule)
+
+		// then
+		Expect(err).To(MatchError(processing.ErrSubresourcesPending))
+
+		vs := networkingv1beta1.VirtualService{}
+		Expect(client.Get(context.TODO(), ctrlclient.ObjectKeyFromObject(&apiRuleVS), &vs)).To(Succeed())
+		Expect(vs.DeletionTimestamp).ToNot(BeNil())
+
+		apList := securityv1beta1.AuthorizationPolicyList{}
+		Expect(client.List(context.TODO(), &apList)).To(Succeed())
+		Expect(apList.Items).To(HaveLen(1))
+
+		raList := securityv1beta1.RequestAuthenticationList{}
+		Expect(client.List(context.TODO(), &raList)).To(Succeed())
+		Expect(raList.Items).To(HaveLen(1))
+
+		// when the virtual service is gone
+		vs.Finalizers = nil
+		Expect(client.Update(context.TODO(), &vs)).To(Succeed())
+		err = processing.DeleteAPIRuleSubresources(client, client, context.TODO(), *apiRule)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(client.List(context.TODO(), &apList)).To(Succeed())
+		Expect(apList.Items).To(BeEmpty())
+		Expect(client.List(context.TODO(), &raList)).To(Succeed())
+		Expect(raList.Items).To(BeEmpty())
 	})
 })
@@ -1,6 +1,7 @@
 package processing_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	apirulev1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
@@ -11,6 +12,10 @@ import (
 	"istio.io/api/networking/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -78,10 +83,34 @@ func GetFakeClient(objs ...client.Object) client.Client {
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	err = securityv1beta1.AddToScheme(scheme)
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	err = networkingv1.AddToScheme(scheme)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	err = telemetryv1alpha1.AddToScheme(scheme)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	err = corev1.AddToScheme(scheme)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	err = apirulev1beta1.AddToScheme(scheme)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
 }
 
+// GetFakeClientWithoutTelemetryAPI returns a client of a cluster in which the Istio Telemetry API isn't available.
+func GetFakeClientWithoutTelemetryAPI(objs ...client.Object) client.Client {
+	return noTelemetryAPIClient{Client: GetFakeClient(objs...)}
+}
+
+type noTelemetryAPIClient struct {
+	client.Client
+}
+
+func (c noTelemetryAPIClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if _, ok := list.(*telemetryv1alpha1.TelemetryList); ok {
+		return &meta.NoKindMatchError{GroupKind: telemetryv1alpha1.SchemeGroupVersion.WithKind("Telemetry").GroupKind(), SearchedVersions: []string{"v1alpha1"}}
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
 func GetRuleFor(path string, methods []string, mutators []*apirulev1beta1.Mutator, accessStrategies []*apirulev1beta1.Authenticator) apirulev1beta1.Rule {
 	return apirulev1beta1.Rule{
 		Path:             path,
@@ -105,4 +105,37 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		Expect(ap1.Spec.Rules[0].When).To(ContainElement(builders.NewConditionBuilder().WithKey("request.auth.claims[aud]").WithValues([]string{TestAudience1}).Get()))
 		Expect(ap1.Spec.Rules[0].When).To(ContainElement(builders.NewConditionBuilder().WithKey("request.auth.claims[aud]").WithValues([]string{TestAudience2}).Get()))
 	})
+
+	It("should produce AP rules only for the configured scope claims", func() {
+		// given
+		jwt := createIstioJwtAccessStrategy()
+		service := &gatewayv1beta1.Service{
+			Name: &ServiceName,
+			Port: &ServicePort,
+		}
+
+		ruleJwt := GetRuleWithServiceFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{jwt}, service)
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{ruleJwt})
+		client := GetFakeClient()
+		config := GetTestConfig()
+		config.ScopeClaims = []string{"roles"}
+		processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+
+		ap1 := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+
+		Expect(ap1).NotTo(BeNil())
+		Expect(ap1.Spec.Rules).To(HaveLen(1))
+		Expect(ap1.Spec.Rules[0].When).To(HaveLen(4))
+		Expect(ap1.Spec.Rules[0].When).To(ContainElement(builders.NewConditionBuilder().WithKey("request.auth.claims[roles]").WithValues([]string{RequiredScopeA}).Get()))
+		Expect(ap1.Spec.Rules[0].When).To(ContainElement(builders.NewConditionBuilder().WithKey("request.auth.claims[roles]").WithValues([]string{RequiredScopeB}).Get()))
+		Expect(ap1.Spec.Rules[0].When).To(ContainElement(builders.NewConditionBuilder().WithKey("request.auth.claims[aud]").WithValues([]string{TestAudience1}).Get()))
+		Expect(ap1.Spec.Rules[0].When).To(ContainElement(builders.NewConditionBuilder().WithKey("request.auth.claims[aud]").WithValues([]string{TestAudience2}).Get()))
+	})
 })
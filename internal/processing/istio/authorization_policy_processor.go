@@ -2,6 +2,7 @@ package istio
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
@@ -22,11 +23,26 @@ var (
 	defaultScopeKeys = []string{"request.auth.claims[scp]", "request.auth.claims[scope]", "request.auth.claims[scopes]"}
 )
 
+// scopeKeysFor returns the AuthorizationPolicy condition keys for the given scope claim names, falling back to the default keys
+// if no claim names are configured.
+func scopeKeysFor(scopeClaims []string) []string {
+	if len(scopeClaims) == 0 {
+		return defaultScopeKeys
+	}
+
+	keys := make([]string, 0, len(scopeClaims))
+	for _, claim := range scopeClaims {
+		keys = append(keys, fmt.Sprintf("request.auth.claims[%s]", claim))
+	}
+	return keys
+}
+
 // NewAuthorizationPolicyProcessor returns a AuthorizationPolicyProcessor with the desired state handling specific for the Istio handler.
 func NewAuthorizationPolicyProcessor(config processing.ReconciliationConfig, log *logr.Logger) processors.AuthorizationPolicyProcessor {
 	return processors.AuthorizationPolicyProcessor{
 		Creator: authorizationPolicyCreator{
 			additionalLabels: config.AdditionalLabels,
+			scopeKeys:        scopeKeysFor(config.ScopeClaims),
 		},
 		Log: log,
 	}
@@ -34,6 +50,7 @@ func NewAuthorizationPolicyProcessor(config processing.ReconciliationConfig, log
 
 type authorizationPolicyCreator struct {
 	additionalLabels map[string]string
+	scopeKeys        []string
 }
 
 // Create returns the JwtAuthorization Policy using the configuration of the APIRule.
@@ -42,7 +59,7 @@ func (r authorizationPolicyCreator) Create(api *gatewayv1beta1.APIRule) (hashbas
 	hasJwtRule := processing.HasJwtRule(api)
 	if hasJwtRule {
 		for _, rule := range api.Spec.Rules {
-			aps, err := generateAuthorizationPolicies(api, rule, r.additionalLabels)
+			aps, err := generateAuthorizationPolicies(api, rule, r.additionalLabels, r.scopeKeys)
 			if err != nil {
 				return state, err
 			}
@@ -60,12 +77,12 @@ func (r authorizationPolicyCreator) Create(api *gatewayv1beta1.APIRule) (hashbas
 	return state, nil
 }
 
-func generateAuthorizationPolicies(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string) (*securityv1beta1.AuthorizationPolicyList, error) {
+func generateAuthorizationPolicies(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string, scopeKeys []string) (*securityv1beta1.AuthorizationPolicyList, error) {
 	authorizationPolicyList := securityv1beta1.AuthorizationPolicyList{}
 	ruleAuthorizations := rule.GetJwtIstioAuthorizations()
 
 	if len(ruleAuthorizations) == 0 {
-		ap := generateAuthorizationPolicy(api, rule, additionalLabels, &gatewayv1beta1.JwtAuthorization{})
+		ap := generateAuthorizationPolicy(api, rule, additionalLabels, scopeKeys, &gatewayv1beta1.JwtAuthorization{})
 
 		// If there is no other authorization we can safely assume that the index of this authorization in the array
 		// in the yaml is 0.
@@ -77,7 +94,7 @@ func generateAuthorizationPolicies(api *gatewayv1beta1.APIRule, rule gatewayv1be
 		authorizationPolicyList.Items = append(authorizationPolicyList.Items, ap)
 	} else {
 		for indexInYaml, authorization := range ruleAuthorizations {
-			ap := generateAuthorizationPolicy(api, rule, additionalLabels, authorization)
+			ap := generateAuthorizationPolicy(api, rule, additionalLabels, scopeKeys, authorization)
 
 			err := hashbasedstate.AddLabelsToAuthorizationPolicy(ap, indexInYaml)
 			if err != nil {
@@ -91,14 +108,14 @@ func generateAuthorizationPolicies(api *gatewayv1beta1.APIRule, rule gatewayv1be
 	return &authorizationPolicyList, nil
 }
 
-func generateAuthorizationPolicy(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string, authorization *gatewayv1beta1.JwtAuthorization) *securityv1beta1.AuthorizationPolicy {
+func generateAuthorizationPolicy(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string, scopeKeys []string, authorization *gatewayv1beta1.JwtAuthorization) *securityv1beta1.AuthorizationPolicy {
 	namePrefix := fmt.Sprintf("%s-", api.ObjectMeta.Name)
 	namespace := helpers.FindServiceNamespace(api, &rule)
 
 	apBuilder := builders.NewAuthorizationPolicyBuilder().
 		WithGenerateName(namePrefix).
 		WithNamespace(namespace).
-		WithSpec(builders.NewAuthorizationPolicySpecBuilder().FromAP(generateAuthorizationPolicySpec(api, rule, authorization)).Get()).
+		WithSpec(builders.NewAuthorizationPolicySpecBuilder().FromAP(generateAuthorizationPolicySpec(api, rule, scopeKeys, authorization)).Get()).
 		WithLabel(processing.OwnerLabel, fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace)).
 		WithLabel(processing.OwnerLabelv1alpha1, fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace))
 
@@ -109,7 +126,7 @@ func generateAuthorizationPolicy(api *gatewayv1beta1.APIRule, rule gatewayv1beta
 	return apBuilder.Get()
 }
 
-func generateAuthorizationPolicySpec(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, authorization *gatewayv1beta1.JwtAuthorization) *v1beta1.AuthorizationPolicy {
+func generateAuthorizationPolicySpec(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, scopeKeys []string, authorization *gatewayv1beta1.JwtAuthorization) *v1beta1.AuthorizationPolicy {
 	var service *gatewayv1beta1.Service
 	if rule.Service != nil {
 		service = rule.Service
@@ -120,9 +137,9 @@ func generateAuthorizationPolicySpec(api *gatewayv1beta1.APIRule, rule gatewayv1
 	authorizationPolicySpecBuilder := builders.NewAuthorizationPolicySpecBuilder().
 		WithSelector(builders.SelectorFromService(service))
 
-	// If RequiredScopes are configured, we need to generate a separate Rule for each scopeKey in defaultScopeKeys
+	// If RequiredScopes are configured, we need to generate a separate Rule for each scopeKey
 	if len(authorization.RequiredScopes) > 0 {
-		for _, scopeKey := range defaultScopeKeys {
+		for _, scopeKey := range scopeKeys {
 			ruleBuilder := baseRuleBuilder(rule)
 			for _, scope := range authorization.RequiredScopes {
 				ruleBuilder.WithWhenCondition(
@@ -158,6 +175,14 @@ func withTo(b *builders.RuleBuilder, rule gatewayv1beta1.Rule) *builders.RuleBui
 					WithMethods(rule.Methods).WithPath("/*").Get()).
 				Get())
 	}
+	// A path that matches with and without a trailing slash results in both paths, since the regex can't be used here
+	if path, ok := strings.CutSuffix(rule.Path, processing.OptionalTrailingSlash); ok {
+		return b.WithTo(
+			builders.NewToBuilder().
+				WithOperation(builders.NewOperationBuilder().
+					WithMethods(rule.Methods).WithPath(path).WithPath(path + "/").Get()).
+				Get())
+	}
 	return b.WithTo(
 		builders.NewToBuilder().
 			WithOperation(builders.NewOperationBuilder().
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/api-gateway/internal/processing/hashbasedstate"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
 	"github.com/kyma-project/api-gateway/internal/processing/istio"
@@ -194,6 +195,34 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		Expect(ap.Spec.Rules[0].To[0].Operation.Paths).To(ContainElement("/*"))
 	})
 
+	It("should produce one AP with the path with and without trailing slash when the trailing slash policy is both", func() {
+		// given
+		jwt := createIstioJwtAccessStrategy()
+		service := &gatewayv1beta1.Service{
+			Name: &ServiceName,
+			Port: &ServicePort,
+		}
+
+		ruleJwt := GetRuleWithServiceFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{jwt}, service)
+		apiRule := processing.ExpandTrailingSlash(GetAPIRuleFor([]gatewayv1beta1.Rule{ruleJwt}), helpers.TRAILING_SLASH_BOTH)
+		client := GetFakeClient()
+		processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+
+		ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+
+		Expect(ap.Spec.Rules).ToNot(BeEmpty())
+		for _, rule := range ap.Spec.Rules {
+			Expect(rule.To[0].Operation.Paths).To(Equal([]string{"/orders", "/orders/"}))
+		}
+	})
+
 	It("should produce two APs for a rule with one issuer and two paths", func() {
 		// given
 		jwt := createIstioJwtAccessStrategy()
@@ -1400,6 +1429,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 			Expect(result).To(ContainElements(newMatcher, deletedMatcher))
 		})
 	}
+
 })
 
 func getRuleForApTest(methods []string, path string, serviceName string, namespace ...string) gatewayv1beta1.Rule {
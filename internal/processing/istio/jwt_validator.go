@@ -10,6 +10,7 @@ import (
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	oryjwt "github.com/kyma-project/api-gateway/internal/types/ory"
 	"github.com/kyma-project/api-gateway/internal/validation"
+	"golang.org/x/net/http/httpguts"
 	apiv1beta1 "istio.io/api/type/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -86,6 +87,18 @@ func (o *handlerValidator) Validate(attributePath string, handler *gatewayv1beta
 			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".fromParams")
 			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: "multiple fromParams are not supported"})
 		}
+		for j, fromHeader := range authentication.FromHeaders {
+			if fromHeader == nil || !httpguts.ValidHeaderFieldName(fromHeader.Name) {
+				attrPath := fmt.Sprintf("%s%s[%d]%s[%d]%s", attributePath, ".config.authentications", i, ".fromHeaders", j, ".name")
+				failures = append(failures, validation.Failure{AttributePath: attrPath, Message: "value is empty or not a valid header name"})
+			}
+		}
+		for j, fromParam := range authentication.FromParams {
+			if fromParam == "" {
+				attrPath := fmt.Sprintf("%s%s[%d]%s[%d]", attributePath, ".config.authentications", i, ".fromParams", j)
+				failures = append(failures, validation.Failure{AttributePath: attrPath, Message: "value is empty"})
+			}
+		}
 	}
 
 	authorizationsFailures := hasInvalidAuthorizations(attributePath, template.Authorizations)
@@ -113,6 +126,10 @@ func checkForOryConfig(attributePath string, handler *gatewayv1beta1.Handler) (p
 		problems = append(problems, validation.Failure{AttributePath: attributePath + ".config" + ".trusted_issuers", Message: "Configuration for trusted_issuers is not supported with Istio handler"})
 	}
 
+	if template.TokenFrom != nil {
+		problems = append(problems, validation.Failure{AttributePath: attributePath + ".config" + ".token_from", Message: "Configuration for token_from is not supported with Istio handler"})
+	}
+
 	return problems
 }
 
@@ -128,6 +145,9 @@ func hasInvalidRequiredScopes(authorization v1beta1.JwtAuthorization) error {
 			return errors.New("scope value is empty")
 		}
 	}
+	if duplicate, ok := findDuplicate(authorization.RequiredScopes); ok {
+		return fmt.Errorf("scope value %s is duplicated", duplicate)
+	}
 	return nil
 }
 
@@ -143,9 +163,23 @@ func hasInvalidAudiences(authorization v1beta1.JwtAuthorization) error {
 			return errors.New("audience value is empty")
 		}
 	}
+	if duplicate, ok := findDuplicate(authorization.Audiences); ok {
+		return fmt.Errorf("audience value %s is duplicated", duplicate)
+	}
 	return nil
 }
 
+func findDuplicate(values []string) (string, bool) {
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if seen[value] {
+			return value, true
+		}
+		seen[value] = true
+	}
+	return "", false
+}
+
 func hasInvalidAuthorizations(attributePath string, authorizations []*v1beta1.JwtAuthorization) (failures []validation.Failure) {
 	if authorizations == nil {
 		return nil
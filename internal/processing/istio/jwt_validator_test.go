@@ -212,6 +212,19 @@ var _ = Describe("JWT Handler validation", func() {
 		Expect(problems).To(Not(BeEmpty()))
 	})
 
+	It("Should fail for config with Ory token_from configuration", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: getRawConfig(ory.JWTAccStrConfig{TokenFrom: &ory.TokenFrom{Header: "x-jwt-assertion"}})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.token_from"))
+		Expect(problems[0].Message).To(Equal("Configuration for token_from is not supported with Istio handler"))
+	})
+
 	Context("for authentications", func() {
 
 		It("Should fail validation when authentication has more than one fromHeaders", func() {
@@ -299,6 +312,138 @@ var _ = Describe("JWT Handler validation", func() {
 			Expect(problems[0].AttributePath).To(Equal(".config.authentications[1].fromHeaders"))
 			Expect(problems[0].Message).To(Equal("mixture of multiple fromHeaders and fromParams is not supported"))
 		})
+
+		It("Should fail validation when authentication has both fromHeaders and fromParams", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:      "https://issuer.test/",
+							JwksUri:     "file://.well-known/jwks.json",
+							FromHeaders: []*gatewayv1beta1.JwtHeader{{Name: "header1"}},
+							FromParams:  []string{"param1"},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications[0].fromParams"))
+			Expect(problems[0].Message).To(Equal("mixture of multiple fromHeaders and fromParams is not supported"))
+		})
+
+		It("Should succeed validation when authentication has a single fromHeaders", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:      "https://issuer.test/",
+							JwksUri:     "file://.well-known/jwks.json",
+							FromHeaders: []*gatewayv1beta1.JwtHeader{{Name: "x-jwt-assertion", Prefix: "Kyma "}},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("Should succeed validation when authentication has a single fromParams", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:     "https://issuer.test/",
+							JwksUri:    "file://.well-known/jwks.json",
+							FromParams: []string{"jwt_token"},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("Should fail validation when fromHeaders has an invalid header name", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:      "https://issuer.test/",
+							JwksUri:     "file://.well-known/jwks.json",
+							FromHeaders: []*gatewayv1beta1.JwtHeader{{Name: "x jwt:assertion"}},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications[0].fromHeaders[0].name"))
+			Expect(problems[0].Message).To(Equal("value is empty or not a valid header name"))
+		})
+
+		It("Should fail validation when fromParams has an empty value", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:     "https://issuer.test/",
+							JwksUri:    "file://.well-known/jwks.json",
+							FromParams: []string{""},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications[0].fromParams[0]"))
+			Expect(problems[0].Message).To(Equal("value is empty"))
+		})
 	})
 
 	Context("for authorizations", func() {
@@ -399,6 +544,27 @@ var _ = Describe("JWT Handler validation", func() {
 				Expect(problems[0].Message).To(Equal("scope value is empty"))
 			})
 
+			It("Should fail for config with duplicated required scopes", func() {
+				//given
+				authorizations := []*gatewayv1beta1.JwtAuthorization{
+					{
+						RequiredScopes: []string{"scope-a", "scope-b", "scope-a"},
+					},
+				}
+				handler := &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: testURLJWTIstioConfigWithAuthorizations(authorizations),
+				}
+
+				//when
+				problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+				//then
+				Expect(problems).To(HaveLen(1))
+				Expect(problems[0].AttributePath).To(Equal("some.attribute.config.authorizations[0].requiredScopes"))
+				Expect(problems[0].Message).To(Equal("scope value scope-a is duplicated"))
+			})
+
 			It("Should succeed for config with two required scopes", func() {
 				//given
 				authorizations := []*gatewayv1beta1.JwtAuthorization{
@@ -482,6 +648,27 @@ var _ = Describe("JWT Handler validation", func() {
 				Expect(problems[0].Message).To(Equal("audience value is empty"))
 			})
 
+			It("Should fail validation for config with duplicated audiences", func() {
+				//given
+				authorizations := []*gatewayv1beta1.JwtAuthorization{
+					{
+						Audiences: []string{"www.example.com", "www.example.com"},
+					},
+				}
+				handler := &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: testURLJWTIstioConfigWithAuthorizations(authorizations),
+				}
+
+				//when
+				problems := (&handlerValidator{}).Validate("", handler)
+
+				//then
+				Expect(problems).To(HaveLen(1))
+				Expect(problems[0].AttributePath).To(Equal(".config.authorizations[0].audiences"))
+				Expect(problems[0].Message).To(Equal("audience value www.example.com is duplicated"))
+			})
+
 			It("Should successful validate config with an audience", func() {
 				//given
 				authorizations := []*gatewayv1beta1.JwtAuthorization{
@@ -6,8 +6,8 @@ import (
 	"github.com/go-logr/logr"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/processing/processors"
 	"github.com/kyma-project/api-gateway/internal/validation"
-	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -21,31 +21,27 @@ func NewIstioReconciliation(config processing.ReconciliationConfig, log *logr.Lo
 	vsProcessor := NewVirtualServiceProcessor(config)
 	apProcessor := NewAuthorizationPolicyProcessor(config, log)
 	raProcessor := NewRequestAuthenticationProcessor(config)
+	npProcessor := processors.NewNetworkPolicyProcessor(config)
+	telemetryProcessor := processors.NewTelemetryProcessor(config)
 
 	return Reconciliation{
-		processors: []processing.ReconciliationProcessor{vsProcessor, raProcessor, apProcessor, acProcessor},
+		processors: []processing.ReconciliationProcessor{vsProcessor, raProcessor, apProcessor, acProcessor, npProcessor, telemetryProcessor},
 		config:     config,
 	}
 }
 
 func (r Reconciliation) Validate(ctx context.Context, client client.Client, apiRule *gatewayv1beta1.APIRule) ([]validation.Failure, error) {
-	var vsList networkingv1beta1.VirtualServiceList
-	if err := client.List(ctx, &vsList); err != nil {
+	validator, err := processing.NewAPIRuleValidator(ctx, client, r.config, apiRule)
+	if err != nil {
 		return make([]validation.Failure, 0), err
 	}
+	validator.HandlerValidator = &handlerValidator{}
+	validator.AccessStrategiesValidator = &asValidator{}
+	validator.MutatorsValidator = &mutatorsValidator{}
+	validator.InjectionValidator = &injectionValidator{ctx: ctx, client: client}
+	validator.RulesValidator = &rulesValidator{}
 
-	validator := validation.APIRuleValidator{
-		HandlerValidator:          &handlerValidator{},
-		AccessStrategiesValidator: &asValidator{},
-		MutatorsValidator:         &mutatorsValidator{},
-		InjectionValidator:        &injectionValidator{ctx: ctx, client: client},
-		RulesValidator:            &rulesValidator{},
-		ServiceBlockList:          r.config.ServiceBlockList,
-		DomainAllowList:           r.config.DomainAllowList,
-		HostBlockList:             r.config.HostBlockList,
-		DefaultDomainName:         r.config.DefaultDomainName,
-	}
-	return validator.Validate(apiRule, vsList), nil
+	return processing.ValidateAPIRule(ctx, client, validator, apiRule)
 }
 
 func (r Reconciliation) GetProcessors() []processing.ReconciliationProcessor {
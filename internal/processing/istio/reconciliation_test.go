@@ -5,14 +5,22 @@ import (
 	"fmt"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
 	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
 	"github.com/kyma-project/api-gateway/internal/processing/istio"
+	"github.com/kyma-project/api-gateway/internal/validation"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apiv1beta1 "istio.io/api/type/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -226,4 +234,217 @@ var _ = Describe("Reconciliation", func() {
 			Expect(vsCreated && oryRuleCreated && raCreated && apCreated == 2).To(BeTrue())
 		})
 	})
+
+	When("validating the quota", func() {
+		allow := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "allow",
+				},
+			},
+		}
+
+		apiRuleWithRules := func(count int) *gatewayv1beta1.APIRule {
+			var rules []gatewayv1beta1.Rule
+			for i := 0; i < count; i++ {
+				rules = append(rules, GetRuleFor(fmt.Sprintf("/path-%d", i), ApiMethods, []*gatewayv1beta1.Mutator{}, allow))
+			}
+			return GetAPIRuleFor(rules)
+		}
+
+		namespaceWithAnnotations := func(annotations map[string]string) *corev1.Namespace {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ApiNamespace, Annotations: annotations}}
+		}
+
+		quotaFailures := func(failures []validation.Failure) []validation.Failure {
+			var result []validation.Failure
+			for _, failure := range failures {
+				if failure.AttributePath == ".spec.rules" {
+					result = append(result, failure)
+				}
+			}
+			return result
+		}
+
+		It("should use the limit of the namespace annotation", func() {
+			// given
+			apiRule := apiRuleWithRules(3)
+			fakeClient := GetFakeClient(namespaceWithAnnotations(map[string]string{helpers.QUOTA_MAX_RULES_ANNOTATION: "2"}))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(quotaFailures(failures)).To(HaveLen(1))
+			Expect(quotaFailures(failures)[0].Message).To(Equal("APIRule defines 3 rules, but only 2 are allowed"))
+		})
+
+		It("should allow more rules than the config if the namespace annotation raises the limit", func() {
+			// given
+			apiRule := apiRuleWithRules(3)
+			config := GetTestConfig()
+			config.Quota.MaxRulesPerAPIRule = 2
+			fakeClient := GetFakeClient(namespaceWithAnnotations(map[string]string{helpers.QUOTA_MAX_RULES_ANNOTATION: "5"}))
+			reconciliation := istio.NewIstioReconciliation(config, &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(quotaFailures(failures)).To(BeEmpty())
+		})
+
+		It("should use the limit of the config if the namespace has no annotation", func() {
+			// given
+			apiRule := apiRuleWithRules(3)
+			config := GetTestConfig()
+			config.Quota.MaxRulesPerAPIRule = 2
+			fakeClient := GetFakeClient(namespaceWithAnnotations(nil))
+			reconciliation := istio.NewIstioReconciliation(config, &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(quotaFailures(failures)).To(HaveLen(1))
+		})
+
+		It("should return error if the namespace annotation is not a valid limit", func() {
+			// given
+			apiRule := apiRuleWithRules(1)
+			fakeClient := GetFakeClient(namespaceWithAnnotations(map[string]string{helpers.QUOTA_MAX_APIRULES_ANNOTATION: "many"}))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			_, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(MatchError(fmt.Sprintf(`value "many" of annotation gateway.kyma-project.io/max-apirules of namespace %s is not a valid limit`, ApiNamespace)))
+		})
+	})
+
+	When("validating the telemetries", func() {
+		tracedAPIRule := func() *gatewayv1beta1.APIRule {
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allow)})
+			apiRule.Spec.Tracing = &gatewayv1beta1.Tracing{SamplingRate: 10}
+			return apiRule
+		}
+
+		telemetryOf := func(owner string) *telemetryv1alpha1.Telemetry {
+			telemetry := &telemetryv1alpha1.Telemetry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "telemetry",
+					Namespace: ApiNamespace,
+					Labels:    map[string]string{processing.OwnerLabelv1alpha1: owner},
+				},
+			}
+			telemetry.Spec.Selector = &apiv1beta1.WorkloadSelector{MatchLabels: map[string]string{TestSelectorKey: ServiceName}}
+			return telemetry
+		}
+
+		It("should fail if another APIRule has a Telemetry for the same workload", func() {
+			// given
+			apiRule := tracedAPIRule()
+			fakeClient := GetFakeClient(telemetryOf(fmt.Sprintf("other-apirule.%s", ApiNamespace)))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0].AttributePath).To(Equal(".spec.service"))
+			Expect(failures[0].Message).To(Equal(fmt.Sprintf("The workload already has a Telemetry for tracing or access logging owned by APIRule other-apirule.%s", ApiNamespace)))
+		})
+
+		It("should succeed if the Telemetry of the workload is owned by the APIRule", func() {
+			// given
+			apiRule := tracedAPIRule()
+			fakeClient := GetFakeClient(telemetryOf(fmt.Sprintf("%s.%s", apiRule.Name, apiRule.Namespace)))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(failures).To(BeEmpty())
+		})
+
+		It("should succeed if the Telemetry API is not available in the cluster", func() {
+			// given
+			apiRule := tracedAPIRule()
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), GetFakeClientWithoutTelemetryAPI(), apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(failures).To(BeEmpty())
+		})
+	})
+
+	When("validating headless services", func() {
+		apiRule := func() *gatewayv1beta1.APIRule {
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			return GetAPIRuleFor([]gatewayv1beta1.Rule{GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allow)})
+		}
+
+		serviceWith := func(clusterIP string, targetPort intstr.IntOrString) *corev1.Service {
+			return &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: ServiceName, Namespace: ApiNamespace},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: clusterIP,
+					Ports:     []corev1.ServicePort{{Port: int32(ServicePort), TargetPort: targetPort}},
+				},
+			}
+		}
+
+		It("should fail if the port of the headless Service targets a named container port", func() {
+			// given
+			fakeClient := GetFakeClient(serviceWith(corev1.ClusterIPNone, intstr.FromString("http")))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule())
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0].AttributePath).To(Equal(".spec.service.port"))
+		})
+
+		It("should succeed if the port of the headless Service targets the same numeric container port", func() {
+			// given
+			fakeClient := GetFakeClient(serviceWith(corev1.ClusterIPNone, intstr.FromInt(int(ServicePort))))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule())
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(failures).To(BeEmpty())
+		})
+
+		It("should succeed for a Service with cluster IP that targets a named container port", func() {
+			// given
+			fakeClient := GetFakeClient(serviceWith("10.0.0.1", intstr.FromString("http")))
+			reconciliation := istio.NewIstioReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule())
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(failures).To(BeEmpty())
+		})
+	})
 })
@@ -79,11 +79,23 @@ func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv
 		} else {
 			httpRouteBuilder.Match(builders.MatchRequest().Uri().Regex(rule.Path))
 		}
-		httpRouteBuilder.CorsPolicy(builders.CorsPolicy().
-			AllowOrigins(r.corsConfig.AllowOrigins...).
-			AllowMethods(r.corsConfig.AllowMethods...).
-			AllowHeaders(r.corsConfig.AllowHeaders...))
-		httpRouteBuilder.Timeout(time.Second * time.Duration(r.httpTimeoutDuration))
+		if api.Spec.CorsPolicy != nil {
+			httpRouteBuilder.CorsPolicy(builders.CorsPolicy().From(api.Spec.CorsPolicy))
+		} else {
+			httpRouteBuilder.CorsPolicy(builders.CorsPolicy().
+				AllowOrigins(r.corsConfig.AllowOrigins...).
+				AllowMethods(r.corsConfig.AllowMethods...).
+				AllowHeaders(r.corsConfig.AllowHeaders...))
+		}
+		httpRouteBuilder.Timeout(helpers.FindTimeout(api, &rule, time.Second*time.Duration(r.httpTimeoutDuration)))
+
+		if retries := helpers.FindRetries(api, &rule); retries != nil {
+			httpRouteBuilder.Retries(builders.HTTPRetry().From(retries))
+		}
+
+		if rule.Fault != nil {
+			httpRouteBuilder.Fault(builders.FaultInjection().From(rule.Fault))
+		}
 
 		headersBuilder := builders.NewHttpRouteHeadersBuilder().
 			SetHostHeader(helpers.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName))
@@ -3,7 +3,11 @@ package istio_test
 import (
 	"context"
 	"fmt"
+	"time"
+
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
 	processingtest "github.com/kyma-project/api-gateway/internal/processing/internal/test"
@@ -942,4 +946,279 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[0].Headers.Request.Set).ToNot(HaveKeyWithValue("x-test-header-1", "header-value1"))
 		})
 	})
+
+	Context("fault is defined", func() {
+		var faultRule = func(fault *gatewayv1beta1.Fault) gatewayv1beta1.Rule {
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			rule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rule.Fault = fault
+			return rule
+		}
+
+		It("should set delay fault on VS", func() {
+			// given
+			rule := faultRule(&gatewayv1beta1.Fault{
+				Delay: &gatewayv1beta1.FaultDelay{FixedDelay: metav1.Duration{Duration: 5 * time.Second}, Percentage: 10},
+			})
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Fault.Abort).To(BeNil())
+			Expect(vs.Spec.Http[0].Fault.Delay.GetFixedDelay().AsDuration()).To(Equal(5 * time.Second))
+			Expect(vs.Spec.Http[0].Fault.Delay.Percentage.Value).To(Equal(float64(10)))
+		})
+
+		It("should set abort fault on VS", func() {
+			// given
+			rule := faultRule(&gatewayv1beta1.Fault{
+				Abort: &gatewayv1beta1.FaultAbort{HttpStatus: 503, Percentage: 50},
+			})
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Fault.Delay).To(BeNil())
+			Expect(vs.Spec.Http[0].Fault.Abort.GetHttpStatus()).To(Equal(int32(503)))
+			Expect(vs.Spec.Http[0].Fault.Abort.Percentage.Value).To(Equal(float64(50)))
+		})
+
+		It("should set delay and abort fault on VS", func() {
+			// given
+			rule := faultRule(&gatewayv1beta1.Fault{
+				Delay: &gatewayv1beta1.FaultDelay{FixedDelay: metav1.Duration{Duration: time.Second}, Percentage: 100},
+				Abort: &gatewayv1beta1.FaultAbort{HttpStatus: 500, Percentage: 1},
+			})
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Fault.Delay.GetFixedDelay().AsDuration()).To(Equal(time.Second))
+			Expect(vs.Spec.Http[0].Fault.Delay.Percentage.Value).To(Equal(float64(100)))
+			Expect(vs.Spec.Http[0].Fault.Abort.GetHttpStatus()).To(Equal(int32(500)))
+			Expect(vs.Spec.Http[0].Fault.Abort.Percentage.Value).To(Equal(float64(1)))
+		})
+
+		It("should not set fault on VS when rule has no fault", func() {
+			// given
+			rule := faultRule(nil)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Fault).To(BeNil())
+		})
+	})
+
+	Context("CORS policy is defined", func() {
+		allowRule := func() gatewayv1beta1.Rule {
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+			return GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+		}
+
+		It("should set CORS policy of the APIRule instead of the default CORS policy", func() {
+			// given
+			allowCredentials := true
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+			apiRule.Spec.CorsPolicy = &gatewayv1beta1.CorsPolicy{
+				AllowOrigins:     []gatewayv1beta1.StringMatch{{Exact: "https://example.com"}, {Prefix: "https://app."}, {Regex: ".*\\.example\\.org"}},
+				AllowMethods:     []string{"GET", "POST"},
+				AllowHeaders:     []string{"Authorization"},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: &allowCredentials,
+				MaxAge:           &metav1.Duration{Duration: 24 * time.Hour},
+			}
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			corsPolicy := result[0].Obj.(*networkingv1beta1.VirtualService).Spec.Http[0].CorsPolicy
+			Expect(corsPolicy.AllowOrigins).To(HaveLen(3))
+			Expect(corsPolicy.AllowOrigins[0].GetExact()).To(Equal("https://example.com"))
+			Expect(corsPolicy.AllowOrigins[1].GetPrefix()).To(Equal("https://app."))
+			Expect(corsPolicy.AllowOrigins[2].GetRegex()).To(Equal(".*\\.example\\.org"))
+			Expect(corsPolicy.AllowMethods).To(Equal([]string{"GET", "POST"}))
+			Expect(corsPolicy.AllowHeaders).To(Equal([]string{"Authorization"}))
+			Expect(corsPolicy.ExposeHeaders).To(Equal([]string{"X-Request-Id"}))
+			Expect(corsPolicy.AllowCredentials.GetValue()).To(BeTrue())
+			Expect(corsPolicy.MaxAge.AsDuration()).To(Equal(24 * time.Hour))
+		})
+
+		It("should reset CORS policy of existing VS to the default CORS policy when it is removed from the APIRule", func() {
+			// given
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+			vs := networkingv1beta1.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "existing-vs",
+					Namespace: ApiNamespace,
+					Labels: map[string]string{
+						processing.OwnerLabelv1alpha1: fmt.Sprintf("%s.%s", apiRule.ObjectMeta.Name, apiRule.ObjectMeta.Namespace),
+					},
+				},
+			}
+			vs.Spec.Http = append(vs.Spec.Http, builders.HTTPRoute().CorsPolicy(builders.CorsPolicy().ExposeHeaders("X-Request-Id").AllowCredentials(true)).Get())
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(&vs), apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Action.String()).To(Equal("update"))
+
+			corsPolicy := result[0].Obj.(*networkingv1beta1.VirtualService).Spec.Http[0].CorsPolicy
+			Expect(corsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
+			Expect(corsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
+			Expect(corsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
+			Expect(corsPolicy.ExposeHeaders).To(BeNil())
+			Expect(corsPolicy.AllowCredentials).To(BeNil())
+		})
+	})
+
+	Context("timeout and retries are defined", func() {
+		allowRule := func(path string) gatewayv1beta1.Rule {
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+			return GetRuleFor(path, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+		}
+
+		It("should set timeout and retries of the spec on rules that don't overwrite them", func() {
+			// given
+			overwritingRule := allowRule("/overwrite")
+			overwritingRule.Timeout = &metav1.Duration{Duration: 5 * time.Second}
+			overwritingRule.Retries = &gatewayv1beta1.Retries{Attempts: 1}
+
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule("/inherit"), overwritingRule})
+			apiRule.Spec.Timeout = &metav1.Duration{Duration: 30 * time.Second}
+			apiRule.Spec.Retries = &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: &metav1.Duration{Duration: 2 * time.Second}, RetryOn: "5xx,connect-failure"}
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Timeout.AsDuration()).To(Equal(30 * time.Second))
+			Expect(vs.Spec.Http[0].Retries.Attempts).To(Equal(int32(3)))
+			Expect(vs.Spec.Http[0].Retries.PerTryTimeout.AsDuration()).To(Equal(2 * time.Second))
+			Expect(vs.Spec.Http[0].Retries.RetryOn).To(Equal("5xx,connect-failure"))
+
+			Expect(vs.Spec.Http[1].Timeout.AsDuration()).To(Equal(5 * time.Second))
+			Expect(vs.Spec.Http[1].Retries.Attempts).To(Equal(int32(1)))
+			Expect(vs.Spec.Http[1].Retries.PerTryTimeout).To(BeNil())
+			Expect(vs.Spec.Http[1].Retries.RetryOn).To(BeEmpty())
+		})
+
+		It("should set default timeout and no retries when they are not defined", func() {
+			// given
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(ApiPath)})
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(vs.Spec.Http[0].Timeout.AsDuration()).To(Equal(time.Second * time.Duration(GetTestConfig().HTTPTimeoutDuration)))
+			Expect(vs.Spec.Http[0].Retries).To(BeNil())
+		})
+	})
+
+	When("the trailing slash policy is both", func() {
+		It("should match the path with and without trailing slash", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			apiRule := processing.ExpandTrailingSlash(GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule}), helpers.TRAILING_SLASH_BOTH)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+
+			resultVs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(resultVs.Spec.Http).To(HaveLen(1))
+			Expect(resultVs.Spec.Http[0].Match).To(HaveLen(1))
+			Expect(resultVs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/orders/?"))
+		})
+	})
 })
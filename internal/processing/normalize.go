@@ -0,0 +1,76 @@
+package processing
+
+import (
+	"regexp"
+	"strings"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+)
+
+// OptionalTrailingSlash is appended to the regex of a rule path that matches with and without a trailing slash.
+const OptionalTrailingSlash = "/?"
+
+var duplicateSlashes = regexp.MustCompile(`//+`)
+
+// NormalizeRules canonicalizes the paths and methods of the rules of the APIRule in place and returns true if anything
+// changed, so that the normalized spec can be persisted. Duplicate slashes of the paths are collapsed, methods are upper
+// cased, and with the strip and both trailing slash policies the trailing slash of the paths is removed.
+func NormalizeRules(apiRule *gatewayv1beta1.APIRule, trailingSlashPolicy string) bool {
+	changed := false
+	for i := range apiRule.Spec.Rules {
+		rule := &apiRule.Spec.Rules[i]
+
+		path := duplicateSlashes.ReplaceAllString(rule.Path, "/")
+		if trailingSlashPolicy == helpers.TRAILING_SLASH_STRIP || trailingSlashPolicy == helpers.TRAILING_SLASH_BOTH {
+			path = stripTrailingSlash(path)
+		}
+		if path != rule.Path {
+			rule.Path = path
+			changed = true
+		}
+
+		for j, method := range rule.Methods {
+			if upper := strings.ToUpper(method); upper != method {
+				rule.Methods[j] = upper
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// ExpandTrailingSlash returns a copy of the APIRule in which the paths of the rules match with and without a trailing
+// slash, if the trailing slash policy is both. Only paths that end with a literal character are expanded, since the
+// end of a regex, e.g. "/.*", can already match a trailing slash. The spec of the APIRule itself keeps the paths
+// without the trailing slash.
+func ExpandTrailingSlash(apiRule *gatewayv1beta1.APIRule, trailingSlashPolicy string) *gatewayv1beta1.APIRule {
+	if trailingSlashPolicy != helpers.TRAILING_SLASH_BOTH {
+		return apiRule
+	}
+
+	expanded := apiRule.DeepCopy()
+	for i := range expanded.Spec.Rules {
+		rule := &expanded.Spec.Rules[i]
+		if endsWithLiteral(rule.Path) {
+			rule.Path += OptionalTrailingSlash
+		}
+	}
+	return expanded
+}
+
+func stripTrailingSlash(path string) string {
+	if len(path) > 1 && strings.HasSuffix(path, "/") && endsWithLiteral(strings.TrimSuffix(path, "/")) {
+		return strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// endsWithLiteral returns true if the path ends with a character that has no special meaning in a regex.
+func endsWithLiteral(path string) bool {
+	if path == "" {
+		return false
+	}
+	last := path[len(path)-1]
+	return last >= 'a' && last <= 'z' || last >= 'A' && last <= 'Z' || last >= '0' && last <= '9' || last == '-' || last == '_'
+}
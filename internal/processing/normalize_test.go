@@ -0,0 +1,116 @@
+package processing_test
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NormalizeRules", func() {
+	apiRuleWith := func(rules ...gatewayv1beta1.Rule) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{Rules: rules},
+		}
+	}
+
+	It("should collapse duplicate slashes of the paths", func() {
+		// given
+		apiRule := apiRuleWith(gatewayv1beta1.Rule{Path: "//orders///items/", Methods: []string{"GET"}})
+
+		// when
+		changed := processing.NormalizeRules(apiRule, "")
+
+		// then
+		Expect(changed).To(BeTrue())
+		Expect(apiRule.Spec.Rules[0].Path).To(Equal("/orders/items/"))
+	})
+
+	It("should upper case the methods", func() {
+		// given
+		apiRule := apiRuleWith(gatewayv1beta1.Rule{Path: "/orders", Methods: []string{"get", "Post", "DELETE"}})
+
+		// when
+		changed := processing.NormalizeRules(apiRule, "")
+
+		// then
+		Expect(changed).To(BeTrue())
+		Expect(apiRule.Spec.Rules[0].Methods).To(Equal([]string{"GET", "POST", "DELETE"}))
+	})
+
+	It("should keep the trailing slash with the keep policy", func() {
+		// given
+		apiRule := apiRuleWith(gatewayv1beta1.Rule{Path: "/orders/", Methods: []string{"GET"}})
+
+		// when
+		changed := processing.NormalizeRules(apiRule, helpers.TRAILING_SLASH_KEEP)
+
+		// then
+		Expect(changed).To(BeFalse())
+		Expect(apiRule.Spec.Rules[0].Path).To(Equal("/orders/"))
+	})
+
+	DescribeTable("should strip the trailing slash",
+		func(policy string, path string, expectedPath string) {
+			// given
+			apiRule := apiRuleWith(gatewayv1beta1.Rule{Path: path, Methods: []string{"GET"}})
+
+			// when
+			processing.NormalizeRules(apiRule, policy)
+
+			// then
+			Expect(apiRule.Spec.Rules[0].Path).To(Equal(expectedPath))
+		},
+		Entry("with the strip policy", helpers.TRAILING_SLASH_STRIP, "/orders/", "/orders"),
+		Entry("with the both policy", helpers.TRAILING_SLASH_BOTH, "/orders/", "/orders"),
+		Entry("but not of the root path", helpers.TRAILING_SLASH_STRIP, "/", "/"),
+		Entry("but not after a regex", helpers.TRAILING_SLASH_STRIP, "/orders.*/", "/orders.*/"),
+	)
+
+	It("should not change a normalized APIRule", func() {
+		// given
+		apiRule := apiRuleWith(gatewayv1beta1.Rule{Path: "/orders", Methods: []string{"GET"}}, gatewayv1beta1.Rule{Path: "/.*", Methods: []string{"POST"}})
+
+		// when
+		changed := processing.NormalizeRules(apiRule, helpers.TRAILING_SLASH_STRIP)
+
+		// then
+		Expect(changed).To(BeFalse())
+	})
+})
+
+var _ = Describe("ExpandTrailingSlash", func() {
+	It("should match paths that end with a literal with and without trailing slash with the both policy", func() {
+		// given
+		apiRule := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{{Path: "/orders"}, {Path: "/.*"}, {Path: "/"}},
+			},
+		}
+
+		// when
+		expanded := processing.ExpandTrailingSlash(apiRule, helpers.TRAILING_SLASH_BOTH)
+
+		// then
+		Expect(expanded.Spec.Rules[0].Path).To(Equal("/orders/?"))
+		Expect(expanded.Spec.Rules[1].Path).To(Equal("/.*"))
+		Expect(expanded.Spec.Rules[2].Path).To(Equal("/"))
+		Expect(apiRule.Spec.Rules[0].Path).To(Equal("/orders"))
+	})
+
+	It("should not change the paths with the strip policy", func() {
+		// given
+		apiRule := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{{Path: "/orders"}},
+			},
+		}
+
+		// when
+		expanded := processing.ExpandTrailingSlash(apiRule, helpers.TRAILING_SLASH_STRIP)
+
+		// then
+		Expect(expanded.Spec.Rules[0].Path).To(Equal("/orders"))
+	})
+})
@@ -1,10 +1,14 @@
 package ory
 
 import (
+	"encoding/json"
+
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/processors"
+	"github.com/kyma-project/api-gateway/internal/types/ory"
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // NewAccessRuleProcessor returns a AccessRuleProcessor with the desired state handling specific for the Ory handler.
@@ -29,9 +33,51 @@ func (r accessRuleCreator) Create(api *gatewayv1beta1.APIRule) map[string]*rulev
 	accessRules := make(map[string]*rulev1alpha1.Rule)
 	for _, rule := range api.Spec.Rules {
 		if processing.IsSecured(rule) {
-			ar := processors.GenerateAccessRule(api, rule, rule.AccessStrategies, r.additionalLabels, r.defaultDomainName)
+			ar := processors.GenerateAccessRule(api, rule, withTokenFrom(rule.AccessStrategies), r.additionalLabels, r.defaultDomainName)
 			accessRules[processors.SetAccessRuleKey(pathDuplicates, *ar)] = ar
 		}
 	}
 	return accessRules
 }
+
+// withTokenFrom returns the access strategies with the fromHeaders and fromParams of the jwt configs replaced by the
+// token_from of the oathkeeper jwt authenticator, which doesn't accept other fields than its own.
+func withTokenFrom(accessStrategies []*gatewayv1beta1.Authenticator) []*gatewayv1beta1.Authenticator {
+	result := make([]*gatewayv1beta1.Authenticator, len(accessStrategies))
+	for i, accessStrategy := range accessStrategies {
+		result[i] = accessStrategy
+		if accessStrategy.Handler == nil || accessStrategy.Handler.Name != "jwt" || accessStrategy.Handler.Config == nil {
+			continue
+		}
+
+		var template ory.JWTAccStrConfig
+		if err := json.Unmarshal(accessStrategy.Handler.Config.Raw, &template); err != nil {
+			continue
+		}
+		tokenFrom := template.TokenFromSources()
+		if tokenFrom == nil {
+			continue
+		}
+
+		var config map[string]json.RawMessage
+		if err := json.Unmarshal(accessStrategy.Handler.Config.Raw, &config); err != nil {
+			continue
+		}
+		delete(config, "fromHeaders")
+		delete(config, "fromParams")
+		rawTokenFrom, err := json.Marshal(tokenFrom)
+		if err != nil {
+			continue
+		}
+		config["token_from"] = rawTokenFrom
+		raw, err := json.Marshal(config)
+		if err != nil {
+			continue
+		}
+
+		converted := accessStrategy.DeepCopy()
+		converted.Handler.Config = &runtime.RawExtension{Raw: raw}
+		result[i] = converted
+	}
+	return result
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
 	"github.com/kyma-project/api-gateway/internal/processing/ory"
@@ -534,6 +535,104 @@ var _ = Describe("Access Rule Processor", func() {
 			Expect(rule.Spec.Upstream.URL).To(Equal(expectedRuleUpstreamURL))
 		})
 	})
+
+	When("the jwt config defines the location of the token with fromHeaders or fromParams", func() {
+		DescribeTable("should map the location to token_from of the jwt authenticator",
+			func(config string, expectedConfig string) {
+				// given
+				jwt := []*gatewayv1beta1.Authenticator{
+					{
+						Handler: &gatewayv1beta1.Handler{
+							Name: "jwt",
+							Config: &runtime.RawExtension{
+								Raw: []byte(config),
+							},
+						},
+					},
+				}
+
+				apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, jwt)})
+				client := GetFakeClient()
+				processor := ory.NewAccessRuleProcessor(GetTestConfig())
+
+				// when
+				result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result).To(HaveLen(1))
+
+				accessRule := result[0].Obj.(*rulev1alpha1.Rule)
+				Expect(accessRule.Spec.Authenticators).To(HaveLen(1))
+				Expect(string(accessRule.Spec.Authenticators[0].Handler.Config.Raw)).To(MatchJSON(expectedConfig))
+				Expect(string(apiRule.Spec.Rules[0].AccessStrategies[0].Config.Raw)).To(Equal(config))
+			},
+			Entry("with a header",
+				fmt.Sprintf(`{"trusted_issuers":["%s"],"jwks_urls":["%s"],"fromHeaders":[{"name":"x-jwt-assertion"}]}`, JwtIssuer, JwksUri),
+				fmt.Sprintf(`{"trusted_issuers":["%s"],"jwks_urls":["%s"],"token_from":{"header":"x-jwt-assertion"}}`, JwtIssuer, JwksUri)),
+			Entry("with a parameter",
+				fmt.Sprintf(`{"trusted_issuers":["%s"],"jwks_urls":["%s"],"fromParams":["jwt_token"]}`, JwtIssuer, JwksUri),
+				fmt.Sprintf(`{"trusted_issuers":["%s"],"jwks_urls":["%s"],"token_from":{"query_parameter":"jwt_token"}}`, JwtIssuer, JwksUri)),
+			Entry("with the Bearer prefix of the Authorization header",
+				fmt.Sprintf(`{"trusted_issuers":["%s"],"fromHeaders":[{"name":"Authorization","prefix":"Bearer "}],"required_scope":["read"]}`, JwtIssuer),
+				fmt.Sprintf(`{"trusted_issuers":["%s"],"token_from":{"header":"Authorization"},"required_scope":["read"]}`, JwtIssuer)),
+		)
+
+		It("should keep the jwt config without fromHeaders and fromParams as it is", func() {
+			// given
+			config := fmt.Sprintf(`{"trusted_issuers": ["%s"], "token_from": {"cookie": "jwt"}}`, JwtIssuer)
+			jwt := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "jwt",
+						Config: &runtime.RawExtension{
+							Raw: []byte(config),
+						},
+					},
+				},
+			}
+
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, jwt)})
+			processor := ory.NewAccessRuleProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			Expect(string(result[0].Obj.(*rulev1alpha1.Rule).Spec.Authenticators[0].Handler.Config.Raw)).To(Equal(config))
+		})
+	})
+
+	When("the trailing slash policy is both", func() {
+		It("should create one access rule that matches the path with and without trailing slash", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "noop",
+					},
+				},
+			}
+
+			noopRule := GetRuleFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			apiRule := processing.ExpandTrailingSlash(GetAPIRuleFor([]gatewayv1beta1.Rule{noopRule}), helpers.TRAILING_SLASH_BOTH)
+			client := GetFakeClient()
+			processor := ory.NewAccessRuleProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			accessRule := result[0].Obj.(*rulev1alpha1.Rule)
+
+			Expect(accessRule.Spec.Match.URL).To(Equal(fmt.Sprintf("<http|https>://%s</orders/?>", ServiceHost)))
+		})
+	})
 })
 
 var idFn = func(index int, element interface{}) string {
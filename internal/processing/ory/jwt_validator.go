@@ -3,10 +3,12 @@ package ory
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/types/ory"
 	"github.com/kyma-project/api-gateway/internal/validation"
+	"golang.org/x/net/http/httpguts"
 )
 
 type handlerValidator struct{}
@@ -61,6 +63,68 @@ func (o *handlerValidator) Validate(attributePath string, handler *gatewayv1beta
 		}
 	}
 
+	if template.TokenFrom != nil {
+		problems = append(problems, hasInvalidTokenFrom(attributePath+".config.token_from", template.TokenFrom)...)
+	}
+
+	if len(template.FromHeaders) > 0 || len(template.FromParams) > 0 {
+		problems = append(problems, hasInvalidTokenSources(attributePath+".config", &template)...)
+	}
+
+	return problems
+}
+
+// hasInvalidTokenSources checks the fromHeaders and fromParams that are mapped to token_from. The oathkeeper jwt
+// authenticator takes the token from a single location, and only strips the Bearer prefix of the Authorization header.
+func hasInvalidTokenSources(attributePath string, template *ory.JWTAccStrConfig) (problems []validation.Failure) {
+	if template.TokenFrom != nil {
+		problems = append(problems, validation.Failure{AttributePath: attributePath, Message: "token_from can't be set together with fromHeaders or fromParams"})
+	}
+	if len(template.FromHeaders)+len(template.FromParams) > 1 {
+		problems = append(problems, validation.Failure{AttributePath: attributePath, Message: "only a single fromHeaders or fromParams entry is supported with Ory handler"})
+	}
+
+	for i, fromHeader := range template.FromHeaders {
+		attrPath := fmt.Sprintf("%s.fromHeaders[%d]", attributePath, i)
+		if !httpguts.ValidHeaderFieldName(fromHeader.Name) {
+			problems = append(problems, validation.Failure{AttributePath: attrPath + ".name", Message: "value is not a valid header name"})
+		}
+		if fromHeader.Prefix != "" && !(strings.EqualFold(fromHeader.Name, "Authorization") && strings.TrimSpace(fromHeader.Prefix) == "Bearer") {
+			problems = append(problems, validation.Failure{AttributePath: attrPath + ".prefix", Message: "only the Bearer prefix of the Authorization header is supported with Ory handler"})
+		}
+	}
+	for i, fromParam := range template.FromParams {
+		if fromParam == "" {
+			problems = append(problems, validation.Failure{AttributePath: fmt.Sprintf("%s.fromParams[%d]", attributePath, i), Message: "value can't be empty"})
+		}
+	}
+
+	return problems
+}
+
+// hasInvalidTokenFrom checks that exactly one location is configured for the token, as the oathkeeper jwt authenticator
+// supports taking the token only from a single header, query parameter or cookie.
+func hasInvalidTokenFrom(attributePath string, tokenFrom *ory.TokenFrom) (problems []validation.Failure) {
+	sources := 0
+	if tokenFrom.Header != "" {
+		sources++
+		if !httpguts.ValidHeaderFieldName(tokenFrom.Header) {
+			problems = append(problems, validation.Failure{AttributePath: attributePath + ".header", Message: "value is not a valid header name"})
+		}
+	}
+	if tokenFrom.QueryParameter != "" {
+		sources++
+	}
+	if tokenFrom.Cookie != "" {
+		sources++
+	}
+
+	if sources == 0 {
+		problems = append(problems, validation.Failure{AttributePath: attributePath, Message: "one of header, query_parameter or cookie must be set"})
+	} else if sources > 1 {
+		problems = append(problems, validation.Failure{AttributePath: attributePath, Message: "only one of header, query_parameter or cookie can be set"})
+	}
+
 	return problems
 }
 
@@ -100,6 +100,168 @@ var _ = Describe("JWT Validator", func() {
 		Expect(problems).To(HaveLen(0))
 	})
 
+	It("Should succeed with token_from header", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenFromJWTConfig(&ory.TokenFrom{Header: "x-jwt-assertion"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed with token_from query_parameter", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenFromJWTConfig(&ory.TokenFrom{QueryParameter: "jwt_token"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed with token_from cookie", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenFromJWTConfig(&ory.TokenFrom{Cookie: "jwt"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail with token_from with multiple sources", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenFromJWTConfig(&ory.TokenFrom{Header: "x-jwt-assertion", Cookie: "jwt"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.token_from"))
+		Expect(problems[0].Message).To(Equal("only one of header, query_parameter or cookie can be set"))
+	})
+
+	It("Should fail with empty token_from", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenFromJWTConfig(&ory.TokenFrom{})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.token_from"))
+		Expect(problems[0].Message).To(Equal("one of header, query_parameter or cookie must be set"))
+	})
+
+	It("Should fail with token_from with invalid header name", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenFromJWTConfig(&ory.TokenFrom{Header: "x jwt:assertion"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.token_from.header"))
+		Expect(problems[0].Message).To(Equal("value is not a valid header name"))
+	})
+
+	It("Should succeed with fromHeaders", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenSourcesJWTConfig([]*gatewayv1beta1.JwtHeader{{Name: "x-jwt-assertion"}}, nil)}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed with fromParams", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenSourcesJWTConfig(nil, []string{"jwt_token"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed with the Bearer prefix of the Authorization header in fromHeaders", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenSourcesJWTConfig([]*gatewayv1beta1.JwtHeader{{Name: "Authorization", Prefix: "Bearer "}}, nil)}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail with fromHeaders and fromParams", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenSourcesJWTConfig([]*gatewayv1beta1.JwtHeader{{Name: "x-jwt-assertion"}}, []string{"jwt_token"})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config"))
+		Expect(problems[0].Message).To(Equal("only a single fromHeaders or fromParams entry is supported with Ory handler"))
+	})
+
+	It("Should fail with fromHeaders and token_from", func() {
+		//given
+		var config ory.JWTAccStrConfig
+		Expect(json.Unmarshal(tokenSourcesJWTConfig([]*gatewayv1beta1.JwtHeader{{Name: "x-jwt-assertion"}}, nil).Raw, &config)).To(Succeed())
+		config.TokenFrom = &ory.TokenFrom{Cookie: "jwt"}
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: getRawConfig(&config)}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config"))
+		Expect(problems[0].Message).To(Equal("token_from can't be set together with fromHeaders or fromParams"))
+	})
+
+	It("Should fail with fromHeaders with invalid header name and unsupported prefix", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenSourcesJWTConfig([]*gatewayv1beta1.JwtHeader{{Name: "x jwt:assertion", Prefix: "Kyma "}}, nil)}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.fromHeaders[0].name"))
+		Expect(problems[0].Message).To(Equal("value is not a valid header name"))
+		Expect(problems[1].AttributePath).To(Equal("some.attribute.config.fromHeaders[0].prefix"))
+		Expect(problems[1].Message).To(Equal("only the Bearer prefix of the Authorization header is supported with Ory handler"))
+	})
+
+	It("Should fail with empty fromParams", func() {
+		//given
+		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: tokenSourcesJWTConfig(nil, []string{""})}
+
+		//when
+		problems := (&handlerValidator{}).Validate("some.attribute", handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.fromParams[0]"))
+		Expect(problems[0].Message).To(Equal("value can't be empty"))
+	})
+
 	It("Should fail for config with Istio JWT configuration", func() {
 		handler := &gatewayv1beta1.Handler{Name: "jwt", Config: testURLJWTIstioConfig("https://issuer.test/.well-known/jwks.json", "https://issuer.test/")}
 
@@ -134,6 +296,25 @@ func testURLJWTConfig(JWKSUrls string, trustedIssuers string) *runtime.RawExtens
 		})
 }
 
+func tokenFromJWTConfig(tokenFrom *ory.TokenFrom) *runtime.RawExtension {
+	return getRawConfig(
+		&ory.JWTAccStrConfig{
+			JWKSUrls:       []string{"https://issuer.test/.well-known/jwks.json"},
+			TrustedIssuers: []string{"https://issuer.test/"},
+			TokenFrom:      tokenFrom,
+		})
+}
+
+func tokenSourcesJWTConfig(fromHeaders []*gatewayv1beta1.JwtHeader, fromParams []string) *runtime.RawExtension {
+	return getRawConfig(
+		&ory.JWTAccStrConfig{
+			JWKSUrls:       []string{"https://issuer.test/.well-known/jwks.json"},
+			TrustedIssuers: []string{"https://issuer.test/"},
+			FromHeaders:    fromHeaders,
+			FromParams:     fromParams,
+		})
+}
+
 func testURLJWTIstioConfig(JWKSUrl string, trustedIssuer string) *runtime.RawExtension {
 	return getRawConfig(
 		gatewayv1beta1.JwtConfig{
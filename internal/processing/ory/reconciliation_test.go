@@ -5,13 +5,17 @@ import (
 	"fmt"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
 	"github.com/kyma-project/api-gateway/internal/processing/ory"
+	"github.com/kyma-project/api-gateway/internal/validation"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
 	"golang.org/x/exp/slices"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -156,4 +160,51 @@ var _ = Describe("Reconciliation", func() {
 			Expect(vsCreated && oryRuleCreated).To(BeTrue())
 		})
 	})
+
+	When("validating the quota", func() {
+		allow := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "allow",
+				},
+			},
+		}
+
+		apiRuleWithRules := func(count int) *gatewayv1beta1.APIRule {
+			var rules []gatewayv1beta1.Rule
+			for i := 0; i < count; i++ {
+				rules = append(rules, GetRuleFor(fmt.Sprintf("/path-%d", i), ApiMethods, []*gatewayv1beta1.Mutator{}, allow))
+			}
+			return GetAPIRuleFor(rules)
+		}
+
+		namespaceWithAnnotations := func(annotations map[string]string) *corev1.Namespace {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ApiNamespace, Annotations: annotations}}
+		}
+
+		quotaFailures := func(failures []validation.Failure) []validation.Failure {
+			var result []validation.Failure
+			for _, failure := range failures {
+				if failure.AttributePath == ".spec.rules" {
+					result = append(result, failure)
+				}
+			}
+			return result
+		}
+
+		It("should use the limit of the namespace annotation", func() {
+			// given
+			apiRule := apiRuleWithRules(3)
+			fakeClient := GetFakeClient(namespaceWithAnnotations(map[string]string{helpers.QUOTA_MAX_RULES_ANNOTATION: "2"}))
+			reconciliation := ory.NewOryReconciliation(GetTestConfig(), &testLogger)
+
+			// when
+			failures, err := reconciliation.Validate(context.TODO(), fakeClient, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(quotaFailures(failures)).To(HaveLen(1))
+			Expect(quotaFailures(failures)[0].Message).To(Equal("APIRule defines 3 rules, but only 2 are allowed"))
+		})
+	})
 })
@@ -62,13 +62,25 @@ func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv
 
 		httpRouteBuilder.Route(builders.RouteDestination().Host(host).Port(port))
 		httpRouteBuilder.Match(builders.MatchRequest().Uri().Regex(rule.Path))
-		httpRouteBuilder.CorsPolicy(builders.CorsPolicy().
-			AllowOrigins(r.corsConfig.AllowOrigins...).
-			AllowMethods(r.corsConfig.AllowMethods...).
-			AllowHeaders(r.corsConfig.AllowHeaders...))
+		if api.Spec.CorsPolicy != nil {
+			httpRouteBuilder.CorsPolicy(builders.CorsPolicy().From(api.Spec.CorsPolicy))
+		} else {
+			httpRouteBuilder.CorsPolicy(builders.CorsPolicy().
+				AllowOrigins(r.corsConfig.AllowOrigins...).
+				AllowMethods(r.corsConfig.AllowMethods...).
+				AllowHeaders(r.corsConfig.AllowHeaders...))
+		}
 		httpRouteBuilder.Headers(builders.NewHttpRouteHeadersBuilder().
 			SetHostHeader(helpers.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName)).Get())
-		httpRouteBuilder.Timeout(time.Second * time.Duration(r.httpTimeoutDuration))
+		httpRouteBuilder.Timeout(helpers.FindTimeout(api, &rule, time.Second*time.Duration(r.httpTimeoutDuration)))
+
+		if retries := helpers.FindRetries(api, &rule); retries != nil {
+			httpRouteBuilder.Retries(builders.HTTPRetry().From(retries))
+		}
+
+		if rule.Fault != nil {
+			httpRouteBuilder.Fault(builders.FaultInjection().From(rule.Fault))
+		}
 		vsSpecBuilder.HTTP(httpRouteBuilder)
 
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
 	"github.com/kyma-project/api-gateway/internal/processing/ory"
@@ -634,4 +635,35 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.ObjectMeta.Labels[TestLabelKey]).To(Equal(TestLabelValue))
 		})
 	})
+
+	When("the trailing slash policy is both", func() {
+		It("should match the path with and without trailing slash", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			apiRule := processing.ExpandTrailingSlash(GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule}), helpers.TRAILING_SLASH_BOTH)
+			client := GetFakeClient()
+			processor := ory.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Match).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/orders/?"))
+		})
+	})
 })
@@ -0,0 +1,136 @@
+package processing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	"golang.org/x/exp/slices"
+	yamlv2 "gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// PresetHandlerName is the name of the access strategy handler that references a preset of the controller config by
+// the name in its config.
+const PresetHandlerName = "preset"
+
+type presetReference struct {
+	Name string `json:"name"`
+}
+
+// ExpandPresets returns a copy of the APIRule in which every access strategy with the preset handler is replaced by the
+// access strategies of the referenced preset. Presets can reference other presets. A reference to an unknown preset
+// and presets that reference each other in a cycle are returned as validation failures.
+func ExpandPresets(apiRule *gatewayv1beta1.APIRule, presets map[string][]helpers.AccessStrategyPreset) (*gatewayv1beta1.APIRule, []validation.Failure, error) {
+	expanded := apiRule.DeepCopy()
+	var failures []validation.Failure
+
+	for i, rule := range expanded.Spec.Rules {
+		attributePath := fmt.Sprintf(".spec.rules[%d].accessStrategies", i)
+		accessStrategies, ruleFailures, err := expandAccessStrategies(attributePath, rule.AccessStrategies, presets, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		expanded.Spec.Rules[i].AccessStrategies = accessStrategies
+		failures = append(failures, ruleFailures...)
+	}
+
+	return expanded, failures, nil
+}
+
+// expandAccessStrategies expands the preset references in the access strategies. The presets that are expanded already
+// are passed in expandedPresets, so that a cycle is detected. Failures in referenced presets are reported on the access
+// strategy of the APIRule that references them.
+func expandAccessStrategies(attributePath string, accessStrategies []*gatewayv1beta1.Authenticator, presets map[string][]helpers.AccessStrategyPreset, expandedPresets []string) ([]*gatewayv1beta1.Authenticator, []validation.Failure, error) {
+	var expanded []*gatewayv1beta1.Authenticator
+	var failures []validation.Failure
+
+	for i, accessStrategy := range accessStrategies {
+		if accessStrategy.Handler == nil || accessStrategy.Handler.Name != PresetHandlerName {
+			expanded = append(expanded, accessStrategy)
+			continue
+		}
+
+		referencePath := attributePath
+		if len(expandedPresets) == 0 {
+			referencePath = fmt.Sprintf("%s[%d]", attributePath, i)
+		}
+
+		var reference presetReference
+		if accessStrategy.Handler.Config != nil {
+			if err := json.Unmarshal(accessStrategy.Handler.Config.Raw, &reference); err != nil {
+				failures = append(failures, validation.Failure{
+					AttributePath: referencePath + ".config",
+					Message:       "Can't read json: " + err.Error(),
+				})
+				continue
+			}
+		}
+
+		if reference.Name == "" {
+			failures = append(failures, validation.Failure{
+				AttributePath: referencePath + ".config.name",
+				Message:       "Preset name is missing",
+			})
+			continue
+		}
+
+		if slices.Contains(expandedPresets, reference.Name) {
+			failures = append(failures, validation.Failure{
+				AttributePath: referencePath + ".config.name",
+				Message:       fmt.Sprintf("Presets reference each other in a cycle: %s -> %s", strings.Join(expandedPresets, " -> "), reference.Name),
+			})
+			continue
+		}
+
+		preset, ok := presets[reference.Name]
+		if !ok {
+			failures = append(failures, validation.Failure{
+				AttributePath: referencePath + ".config.name",
+				Message:       fmt.Sprintf("Preset %s is not defined", reference.Name),
+			})
+			continue
+		}
+
+		presetAccessStrategies, err := toAuthenticators(preset)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading preset %s: %w", reference.Name, err)
+		}
+
+		presetPath := append(append([]string{}, expandedPresets...), reference.Name)
+		presetExpanded, presetFailures, err := expandAccessStrategies(referencePath, presetAccessStrategies, presets, presetPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		expanded = append(expanded, presetExpanded...)
+		failures = append(failures, presetFailures...)
+	}
+
+	return expanded, failures, nil
+}
+
+// toAuthenticators converts the access strategies of a preset, whose config is read from YAML, to authenticators with a
+// JSON config.
+func toAuthenticators(preset []helpers.AccessStrategyPreset) ([]*gatewayv1beta1.Authenticator, error) {
+	authenticators := make([]*gatewayv1beta1.Authenticator, 0, len(preset))
+	for _, accessStrategy := range preset {
+		handler := &gatewayv1beta1.Handler{Name: accessStrategy.Handler}
+		if accessStrategy.Config != nil {
+			config, err := yamlv2.Marshal(accessStrategy.Config)
+			if err != nil {
+				return nil, err
+			}
+			raw, err := yaml.YAMLToJSON(config)
+			if err != nil {
+				return nil, err
+			}
+			handler.Config = &runtime.RawExtension{Raw: raw}
+		}
+		authenticators = append(authenticators, &gatewayv1beta1.Authenticator{Handler: handler})
+	}
+	return authenticators, nil
+}
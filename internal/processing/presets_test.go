@@ -0,0 +1,125 @@
+package processing_test
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("ExpandPresets", func() {
+	presetReference := func(name string) *gatewayv1beta1.Authenticator {
+		return &gatewayv1beta1.Authenticator{
+			Handler: &gatewayv1beta1.Handler{
+				Name:   processing.PresetHandlerName,
+				Config: &runtime.RawExtension{Raw: []byte(`{"name":"` + name + `"}`)},
+			},
+		}
+	}
+
+	apiRuleWith := func(accessStrategies ...*gatewayv1beta1.Authenticator) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{{Path: "/", AccessStrategies: accessStrategies}},
+			},
+		}
+	}
+
+	It("should replace the preset reference with the access strategies of the preset", func() {
+		// given
+		noop := &gatewayv1beta1.Authenticator{Handler: &gatewayv1beta1.Handler{Name: "noop"}}
+		apiRule := apiRuleWith(presetReference("team-a-jwt"), noop)
+		presets := map[string][]helpers.AccessStrategyPreset{
+			"team-a-jwt": {
+				{
+					Handler: "jwt",
+					Config: map[interface{}]interface{}{
+						"authentications": []interface{}{
+							map[interface{}]interface{}{"issuer": "https://issuer.example.com", "jwksUri": "https://issuer.example.com/jwks"},
+						},
+					},
+				},
+			},
+		}
+
+		// when
+		expanded, failures, err := processing.ExpandPresets(apiRule, presets)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failures).To(BeEmpty())
+		Expect(expanded.Spec.Rules[0].AccessStrategies).To(HaveLen(2))
+		Expect(expanded.Spec.Rules[0].AccessStrategies[0].Handler.Name).To(Equal("jwt"))
+		Expect(expanded.Spec.Rules[0].AccessStrategies[0].Handler.Config.Raw).To(MatchJSON(`{"authentications":[{"issuer":"https://issuer.example.com","jwksUri":"https://issuer.example.com/jwks"}]}`))
+		Expect(expanded.Spec.Rules[0].AccessStrategies[1].Handler.Name).To(Equal("noop"))
+
+		Expect(apiRule.Spec.Rules[0].AccessStrategies[0].Handler.Name).To(Equal(processing.PresetHandlerName))
+	})
+
+	It("should expand presets that reference other presets", func() {
+		// given
+		apiRule := apiRuleWith(presetReference("team-a"))
+		presets := map[string][]helpers.AccessStrategyPreset{
+			"team-a": {{Handler: processing.PresetHandlerName, Config: map[interface{}]interface{}{"name": "base"}}, {Handler: "noop"}},
+			"base":   {{Handler: "allow"}},
+		}
+
+		// when
+		expanded, failures, err := processing.ExpandPresets(apiRule, presets)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failures).To(BeEmpty())
+		Expect(expanded.Spec.Rules[0].AccessStrategies).To(HaveLen(2))
+		Expect(expanded.Spec.Rules[0].AccessStrategies[0].Handler.Name).To(Equal("allow"))
+		Expect(expanded.Spec.Rules[0].AccessStrategies[1].Handler.Name).To(Equal("noop"))
+	})
+
+	It("should fail for an unknown preset", func() {
+		// given
+		apiRule := apiRuleWith(&gatewayv1beta1.Authenticator{Handler: &gatewayv1beta1.Handler{Name: "noop"}}, presetReference("unknown"))
+
+		// when
+		_, failures, err := processing.ExpandPresets(apiRule, map[string][]helpers.AccessStrategyPreset{})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failures).To(HaveLen(1))
+		Expect(failures[0].AttributePath).To(Equal(".spec.rules[0].accessStrategies[1].config.name"))
+		Expect(failures[0].Message).To(Equal("Preset unknown is not defined"))
+	})
+
+	It("should fail for a preset reference without name", func() {
+		// given
+		apiRule := apiRuleWith(&gatewayv1beta1.Authenticator{Handler: &gatewayv1beta1.Handler{Name: processing.PresetHandlerName}})
+
+		// when
+		_, failures, err := processing.ExpandPresets(apiRule, map[string][]helpers.AccessStrategyPreset{})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failures).To(HaveLen(1))
+		Expect(failures[0].AttributePath).To(Equal(".spec.rules[0].accessStrategies[0].config.name"))
+		Expect(failures[0].Message).To(Equal("Preset name is missing"))
+	})
+
+	It("should fail for presets that reference each other in a cycle", func() {
+		// given
+		apiRule := apiRuleWith(presetReference("team-a"))
+		presets := map[string][]helpers.AccessStrategyPreset{
+			"team-a": {{Handler: processing.PresetHandlerName, Config: map[interface{}]interface{}{"name": "team-b"}}},
+			"team-b": {{Handler: processing.PresetHandlerName, Config: map[interface{}]interface{}{"name": "team-a"}}},
+		}
+
+		// when
+		_, failures, err := processing.ExpandPresets(apiRule, presets)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failures).To(HaveLen(1))
+		Expect(failures[0].AttributePath).To(Equal(".spec.rules[0].accessStrategies[0].config.name"))
+		Expect(failures[0].Message).To(Equal("Presets reference each other in a cycle: team-a -> team-b -> team-a"))
+	})
+})
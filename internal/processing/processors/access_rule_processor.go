@@ -42,16 +42,16 @@ func (r AccessRuleProcessor) getObjectChanges(desiredRules map[string]*rulev1alp
 
 		if actualRules[path] != nil {
 			actualRules[path].Spec = rule.Spec
-			arChanges[path] = processing.NewObjectUpdateAction(actualRules[path])
+			arChanges[path] = processing.NewObjectUpdateAction(processing.PolicyClass, actualRules[path])
 		} else {
-			arChanges[path] = processing.NewObjectCreateAction(rule)
+			arChanges[path] = processing.NewObjectCreateAction(processing.PolicyClass, rule)
 		}
 
 	}
 
 	for path, rule := range actualRules {
 		if desiredRules[path] == nil {
-			arChanges[path] = processing.NewObjectDeleteAction(rule)
+			arChanges[path] = processing.NewObjectDeleteAction(processing.PolicyClass, rule)
 		}
 	}
 
@@ -70,15 +70,15 @@ func (r AuthorizationPolicyProcessor) getObjectChanges(desired hashbasedstate.De
 	r.Log.Info("Authorization policy changes that will be applied", "changes", changes)
 
 	for _, ap := range changes.Create {
-		apObjectActionsToApply = append(apObjectActionsToApply, processing.NewObjectCreateAction(ap))
+		apObjectActionsToApply = append(apObjectActionsToApply, processing.NewObjectCreateAction(processing.PolicyClass, ap))
 	}
 
 	for _, ap := range changes.Update {
-		apObjectActionsToApply = append(apObjectActionsToApply, processing.NewObjectUpdateAction(ap))
+		apObjectActionsToApply = append(apObjectActionsToApply, processing.NewObjectUpdateAction(processing.PolicyClass, ap))
 	}
 
 	for _, ap := range changes.Delete {
-		apObjectActionsToApply = append(apObjectActionsToApply, processing.NewObjectDeleteAction(ap))
+		apObjectActionsToApply = append(apObjectActionsToApply, processing.NewObjectDeleteAction(processing.PolicyClass, ap))
 	}
 
 	return apObjectActionsToApply
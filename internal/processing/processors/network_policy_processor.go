@@ -0,0 +1,169 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	istioIngressGatewayNamespace = "istio-system"
+	oathkeeperNamespace          = "kyma-system"
+)
+
+var (
+	istioIngressGatewayPodLabels = map[string]string{"istio": "ingressgateway"}
+	oathkeeperPodLabels          = map[string]string{"app.kubernetes.io/name": "oathkeeper"}
+)
+
+// NewNetworkPolicyProcessor returns a NetworkPolicyProcessor that restricts the ingress traffic of the exposed workloads
+// if network policies are enabled in the configuration.
+func NewNetworkPolicyProcessor(config processing.ReconciliationConfig) NetworkPolicyProcessor {
+	return NetworkPolicyProcessor{
+		Creator: networkPolicyCreator{
+			enabled:          config.NetworkPolicyEnabled,
+			additionalLabels: config.AdditionalLabels,
+		},
+	}
+}
+
+// NetworkPolicyProcessor is the generic processor that handles the NetworkPolicies in the reconciliation of API Rule.
+type NetworkPolicyProcessor struct {
+	Creator NetworkPolicyCreator
+}
+
+// NetworkPolicyCreator provides the creation of NetworkPolicies using the configuration in the given APIRule.
+// The key of the map is the namespace and name of the exposed service.
+type NetworkPolicyCreator interface {
+	Create(api *gatewayv1beta1.APIRule) map[string]*networkingv1.NetworkPolicy
+}
+
+func (r NetworkPolicyProcessor) EvaluateReconciliation(ctx context.Context, client ctrlclient.Client, apiRule *gatewayv1beta1.APIRule) ([]*processing.ObjectChange, error) {
+	desired := r.Creator.Create(apiRule)
+	actual, err := r.getActualState(ctx, client, apiRule)
+	if err != nil {
+		return make([]*processing.ObjectChange, 0), err
+	}
+
+	return r.getObjectChanges(desired, actual), nil
+}
+
+// EvaluateWarnings returns a warning for each NetworkPolicy that is not created by an APIRule, but selects the same pods
+// as a NetworkPolicy of the APIRule, since the allowed traffic of all policies selecting a pod is combined.
+func (r NetworkPolicyProcessor) EvaluateWarnings(ctx context.Context, client ctrlclient.Client, apiRule *gatewayv1beta1.APIRule) ([]string, error) {
+	return r.findOverlappingPolicies(ctx, client, r.Creator.Create(apiRule))
+}
+
+func (r NetworkPolicyProcessor) getActualState(ctx context.Context, client ctrlclient.Client, api *gatewayv1beta1.APIRule) (map[string]*networkingv1.NetworkPolicy, error) {
+	ownerLabels := processing.GetOwnerLabels(api)
+
+	var npList networkingv1.NetworkPolicyList
+	if err := client.List(ctx, &npList, ctrlclient.MatchingLabels(ownerLabels)); err != nil {
+		return nil, err
+	}
+
+	networkPolicies := make(map[string]*networkingv1.NetworkPolicy)
+	for i := range npList.Items {
+		np := npList.Items[i]
+		networkPolicies[workloadKey(np.Namespace, np.Spec.PodSelector.MatchLabels)] = &np
+	}
+
+	return networkPolicies, nil
+}
+
+func (r NetworkPolicyProcessor) getObjectChanges(desired map[string]*networkingv1.NetworkPolicy, actual map[string]*networkingv1.NetworkPolicy) []*processing.ObjectChange {
+	return getWorkloadObjectChanges(processing.PolicyClass, desired, actual, func(desired *networkingv1.NetworkPolicy, actual *networkingv1.NetworkPolicy) {
+		actual.Spec = *desired.Spec.DeepCopy()
+	})
+}
+
+func (r NetworkPolicyProcessor) findOverlappingPolicies(ctx context.Context, client ctrlclient.Client, desired map[string]*networkingv1.NetworkPolicy) ([]string, error) {
+	var warnings []string
+	for _, desiredNp := range desired {
+		var npList networkingv1.NetworkPolicyList
+		if err := client.List(ctx, &npList, ctrlclient.InNamespace(desiredNp.Namespace)); err != nil {
+			return nil, err
+		}
+
+		for _, np := range npList.Items {
+			if _, ok := np.Labels[processing.OwnerLabelv1alpha1]; ok {
+				continue
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(&np.Spec.PodSelector)
+			if err != nil {
+				continue
+			}
+
+			if selector.Matches(labels.Set(desiredNp.Spec.PodSelector.MatchLabels)) {
+				warnings = append(warnings, fmt.Sprintf("NetworkPolicy %s/%s selects the same pods as the NetworkPolicy of the APIRule", np.Namespace, np.Name))
+			}
+		}
+	}
+
+	// The desired NetworkPolicies are a map, so the warnings are sorted to keep the status description stable
+	sort.Strings(warnings)
+	return warnings, nil
+}
+
+type networkPolicyCreator struct {
+	enabled          bool
+	additionalLabels map[string]string
+}
+
+// Create returns a NetworkPolicy for each service exposed by the APIRule. If network policies are disabled, no NetworkPolicies
+// are returned, so that existing ones are deleted.
+func (r networkPolicyCreator) Create(api *gatewayv1beta1.APIRule) map[string]*networkingv1.NetworkPolicy {
+	networkPolicies := make(map[string]*networkingv1.NetworkPolicy)
+	if !r.enabled {
+		return networkPolicies
+	}
+
+	for _, rule := range api.Spec.Rules {
+		service := api.Spec.Service
+		if rule.Service != nil {
+			service = rule.Service
+		}
+		if service == nil {
+			continue
+		}
+
+		namespace := helpers.FindServiceNamespace(api, &rule)
+		podLabels := builders.SelectorFromService(service).MatchLabels
+		key := workloadKey(namespace, podLabels)
+		if networkPolicies[key] != nil {
+			continue
+		}
+
+		networkPolicies[key] = r.generateNetworkPolicy(api, namespace, podLabels)
+	}
+
+	return networkPolicies
+}
+
+func (r networkPolicyCreator) generateNetworkPolicy(api *gatewayv1beta1.APIRule, namespace string, podLabels map[string]string) *networkingv1.NetworkPolicy {
+	npBuilder := builders.NetworkPolicy().
+		GenerateName(fmt.Sprintf("%s-", api.ObjectMeta.Name)).
+		Namespace(namespace).
+		PodSelector(podLabels).
+		AllowFromNamespacePods(istioIngressGatewayNamespace, istioIngressGatewayPodLabels).
+		AllowFromNamespacePods(oathkeeperNamespace, oathkeeperPodLabels).
+		AllowFromSameNamespace().
+		Label(processing.OwnerLabel, fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace)).
+		Label(processing.OwnerLabelv1alpha1, fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace))
+
+	for k, v := range r.additionalLabels {
+		npBuilder.Label(k, v)
+	}
+
+	return npBuilder.Get()
+}
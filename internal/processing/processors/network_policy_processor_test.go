@@ -0,0 +1,272 @@
+package processors_test
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
+	"github.com/kyma-project/api-gateway/internal/processing/processors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Network Policy Processor", func() {
+	allowRule := func(service *gatewayv1beta1.Service) gatewayv1beta1.Rule {
+		strategies := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "allow",
+				},
+			},
+		}
+		return GetRuleWithServiceFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies, service)
+	}
+
+	enabledConfig := func() processing.ReconciliationConfig {
+		config := GetTestConfig()
+		config.NetworkPolicyEnabled = true
+		return config
+	}
+
+	ownedNetworkPolicy := func(apiRule *gatewayv1beta1.APIRule, namespace string, serviceName string) *networkingv1.NetworkPolicy {
+		return &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "owned-np",
+				Namespace: namespace,
+				Labels: map[string]string{
+					processing.OwnerLabelv1alpha1: fmt.Sprintf("%s.%s", apiRule.ObjectMeta.Name, apiRule.ObjectMeta.Namespace),
+				},
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{TestSelectorKey: serviceName}},
+			},
+		}
+	}
+
+	It("should create network policy restricting ingress to the exposed service", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("create"))
+
+		np := result[0].Obj.(*networkingv1.NetworkPolicy)
+		Expect(np.GenerateName).To(Equal(ApiName + "-"))
+		Expect(np.Namespace).To(Equal(ApiNamespace))
+		Expect(np.Labels[TestLabelKey]).To(Equal(TestLabelValue))
+		Expect(np.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{TestSelectorKey: ServiceName}))
+		Expect(np.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+		Expect(np.Spec.Ingress).To(HaveLen(1))
+		Expect(np.Spec.Ingress[0].From).To(HaveLen(3))
+		Expect(np.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{"kubernetes.io/metadata.name": "istio-system"}))
+		Expect(np.Spec.Ingress[0].From[0].PodSelector.MatchLabels).To(Equal(map[string]string{"istio": "ingressgateway"}))
+		Expect(np.Spec.Ingress[0].From[1].NamespaceSelector.MatchLabels).To(Equal(map[string]string{"kubernetes.io/metadata.name": "kyma-system"}))
+		Expect(np.Spec.Ingress[0].From[1].PodSelector.MatchLabels).To(Equal(map[string]string{"app.kubernetes.io/name": "oathkeeper"}))
+		Expect(np.Spec.Ingress[0].From[2].NamespaceSelector).To(BeNil())
+		Expect(np.Spec.Ingress[0].From[2].PodSelector.MatchLabels).To(BeEmpty())
+	})
+
+	It("should create a network policy for each exposed service", func() {
+		// given
+		otherServiceName := "other-service"
+		otherNamespace := "other-namespace"
+		otherService := &gatewayv1beta1.Service{Name: &otherServiceName, Namespace: &otherNamespace, Port: &ServicePort}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil), allowRule(otherService)})
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+
+		var namespaces []string
+		for _, change := range result {
+			Expect(change.Action.String()).To(Equal("create"))
+			namespaces = append(namespaces, change.Obj.GetNamespace())
+		}
+		Expect(namespaces).To(ConsistOf(ApiNamespace, otherNamespace))
+	})
+
+	It("should return the changes sorted by namespace and pod labels of the workloads", func() {
+		// given
+		otherServiceName := "other-service"
+		otherNamespace := "other-namespace"
+		otherService := &gatewayv1beta1.Service{Name: &otherServiceName, Namespace: &otherNamespace, Port: &ServicePort}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil), allowRule(otherService)})
+		client := GetFakeClient(ownedNetworkPolicy(apiRule, ApiNamespace, "old-service"))
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		for i := 0; i < 10; i++ {
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].Action.String()).To(Equal("create"))
+			Expect(result[0].Obj.GetNamespace()).To(Equal(otherNamespace))
+			Expect(result[1].Action.String()).To(Equal("create"))
+			Expect(result[1].Obj.GetNamespace()).To(Equal(ApiNamespace))
+			Expect(result[2].Action.String()).To(Equal("delete"))
+			for _, change := range result {
+				Expect(change.Class).To(Equal(processing.PolicyClass))
+			}
+		}
+	})
+
+	It("should update existing network policy of the APIRule", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		client := GetFakeClient(ownedNetworkPolicy(apiRule, ApiNamespace, ServiceName))
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("update"))
+		Expect(result[0].Obj.(*networkingv1.NetworkPolicy).Spec.Ingress).To(HaveLen(1))
+	})
+
+	It("should delete network policy of a service that is no longer exposed", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		client := GetFakeClient(ownedNetworkPolicy(apiRule, ApiNamespace, "old-service"))
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+
+		actions := []string{result[0].Action.String(), result[1].Action.String()}
+		Expect(actions).To(ConsistOf("create", "delete"))
+	})
+
+	It("should delete existing network policies when network policies are disabled", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		client := GetFakeClient(ownedNetworkPolicy(apiRule, ApiNamespace, ServiceName))
+		processor := processors.NewNetworkPolicyProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("delete"))
+	})
+
+	It("should not create network policies when network policies are disabled", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		processor := processors.NewNetworkPolicyProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should return warning for a user network policy selecting the same pods", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		userNp := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user-np",
+				Namespace: ApiNamespace,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{TestSelectorKey: ServiceName}},
+			},
+		}
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(userNp), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(warnings).To(ConsistOf(fmt.Sprintf("NetworkPolicy %s/user-np selects the same pods as the NetworkPolicy of the APIRule", ApiNamespace)))
+	})
+
+	It("should not return warning for network policies of APIRules or selecting other pods", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		otherNp := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-np",
+				Namespace: ApiNamespace,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{TestSelectorKey: "other-service"}},
+			},
+		}
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(otherNp, ownedNetworkPolicy(apiRule, ApiNamespace, ServiceName)), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should not return warning when network policies are disabled", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		userNp := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user-np",
+				Namespace: ApiNamespace,
+			},
+		}
+		processor := processors.NewNetworkPolicyProcessor(GetTestConfig())
+
+		// when
+		warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(userNp), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should still create network policy when a user network policy selects the same pods", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule(nil)})
+		userNp := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user-np",
+				Namespace: ApiNamespace,
+			},
+		}
+		processor := processors.NewNetworkPolicyProcessor(enabledConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(userNp), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("create"))
+	})
+})
@@ -64,16 +64,16 @@ func (r RequestAuthenticationProcessor) getObjectChanges(desiredRas map[string]*
 
 		if actualRas[path] != nil {
 			actualRas[path].Spec = *rule.Spec.DeepCopy()
-			raChanges[path] = processing.NewObjectUpdateAction(actualRas[path])
+			raChanges[path] = processing.NewObjectUpdateAction(processing.AuthenticationClass, actualRas[path])
 		} else {
-			raChanges[path] = processing.NewObjectCreateAction(rule)
+			raChanges[path] = processing.NewObjectCreateAction(processing.AuthenticationClass, rule)
 		}
 
 	}
 
 	for path, rule := range actualRas {
 		if desiredRas[path] == nil {
-			raChanges[path] = processing.NewObjectDeleteAction(rule)
+			raChanges[path] = processing.NewObjectDeleteAction(processing.AuthenticationClass, rule)
 		}
 	}
 
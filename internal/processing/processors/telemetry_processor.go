@@ -0,0 +1,155 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewTelemetryProcessor returns a TelemetryProcessor that configures the tracing and the access logs of the exposed workloads.
+func NewTelemetryProcessor(config processing.ReconciliationConfig) TelemetryProcessor {
+	accessLogProvider := config.AccessLogProvider
+	if accessLogProvider == "" {
+		accessLogProvider = helpers.DEFAULT_ACCESS_LOG_PROVIDER
+	}
+
+	return TelemetryProcessor{
+		Creator: telemetryCreator{
+			additionalLabels:  config.AdditionalLabels,
+			accessLogProvider: accessLogProvider,
+		},
+	}
+}
+
+// TelemetryProcessor is the generic processor that handles the Istio Telemetries in the reconciliation of API Rule.
+type TelemetryProcessor struct {
+	Creator TelemetryCreator
+}
+
+// TelemetryCreator provides the creation of Telemetries using the configuration in the given APIRule.
+// The key of the map is the namespace and the selector of the exposed workload.
+type TelemetryCreator interface {
+	Create(api *gatewayv1beta1.APIRule) map[string]*telemetryv1alpha1.Telemetry
+}
+
+func (r TelemetryProcessor) EvaluateReconciliation(ctx context.Context, client ctrlclient.Client, apiRule *gatewayv1beta1.APIRule) ([]*processing.ObjectChange, error) {
+	desired := r.Creator.Create(apiRule)
+	actual, err := r.getActualState(ctx, client, apiRule)
+	if meta.IsNoMatchError(err) {
+		// Without the Telemetry API in the cluster there are no Telemetries to delete, and the desired ones can't be
+		// created. The workloads are still exposed, and EvaluateWarnings reports the missing API in the status.
+		return make([]*processing.ObjectChange, 0), nil
+	}
+	if err != nil {
+		return make([]*processing.ObjectChange, 0), err
+	}
+
+	return r.getObjectChanges(desired, actual), nil
+}
+
+// EvaluateWarnings returns a warning if the APIRule configures tracing or access logging, but the Telemetry API isn't
+// available in the cluster, so that no Telemetries are created.
+func (r TelemetryProcessor) EvaluateWarnings(ctx context.Context, client ctrlclient.Client, apiRule *gatewayv1beta1.APIRule) ([]string, error) {
+	if len(r.Creator.Create(apiRule)) == 0 {
+		return nil, nil
+	}
+
+	var telemetryList telemetryv1alpha1.TelemetryList
+	err := client.List(ctx, &telemetryList, ctrlclient.Limit(1))
+	if meta.IsNoMatchError(err) {
+		return []string{fmt.Sprintf("tracing and access logging are not configured, because the Telemetry API %s is not available in the cluster", telemetryv1alpha1.SchemeGroupVersion)}, nil
+	}
+	return nil, err
+}
+
+func (r TelemetryProcessor) getActualState(ctx context.Context, client ctrlclient.Client, api *gatewayv1beta1.APIRule) (map[string]*telemetryv1alpha1.Telemetry, error) {
+	ownerLabels := processing.GetOwnerLabels(api)
+
+	var telemetryList telemetryv1alpha1.TelemetryList
+	if err := client.List(ctx, &telemetryList, ctrlclient.MatchingLabels(ownerLabels)); err != nil {
+		return nil, err
+	}
+
+	telemetries := make(map[string]*telemetryv1alpha1.Telemetry)
+	for _, t := range telemetryList.Items {
+		var matchLabels map[string]string
+		if t.Spec.Selector != nil {
+			matchLabels = t.Spec.Selector.MatchLabels
+		}
+		telemetries[workloadKey(t.Namespace, matchLabels)] = t
+	}
+
+	return telemetries, nil
+}
+
+func (r TelemetryProcessor) getObjectChanges(desired map[string]*telemetryv1alpha1.Telemetry, actual map[string]*telemetryv1alpha1.Telemetry) []*processing.ObjectChange {
+	return getWorkloadObjectChanges(processing.PolicyClass, desired, actual, func(desired *telemetryv1alpha1.Telemetry, actual *telemetryv1alpha1.Telemetry) {
+		actual.Spec = *desired.Spec.DeepCopy()
+	})
+}
+
+type telemetryCreator struct {
+	additionalLabels  map[string]string
+	accessLogProvider string
+}
+
+// Create returns a Telemetry for each workload exposed by the APIRule. Tracing and access logging are configured in the
+// same Telemetry, since Istio applies only one Telemetry with a selector to a workload. If neither is configured, no
+// Telemetries are returned, so that existing ones are deleted.
+func (r telemetryCreator) Create(api *gatewayv1beta1.APIRule) map[string]*telemetryv1alpha1.Telemetry {
+	telemetries := make(map[string]*telemetryv1alpha1.Telemetry)
+	if api.Spec.Tracing == nil && api.Spec.AccessLogging == nil {
+		return telemetries
+	}
+
+	for _, rule := range api.Spec.Rules {
+		service := api.Spec.Service
+		if rule.Service != nil {
+			service = rule.Service
+		}
+		if service == nil {
+			continue
+		}
+
+		namespace := helpers.FindServiceNamespace(api, &rule)
+		selector := builders.SelectorFromService(service)
+		key := workloadKey(namespace, selector.MatchLabels)
+		if telemetries[key] != nil {
+			continue
+		}
+
+		telemetryBuilder := builders.Telemetry().
+			GenerateName(fmt.Sprintf("%s-", api.ObjectMeta.Name)).
+			Namespace(namespace).
+			Selector(selector).
+			Label(processing.OwnerLabel, fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace)).
+			Label(processing.OwnerLabelv1alpha1, fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace))
+
+		if api.Spec.Tracing != nil {
+			telemetryBuilder.Tracing(api.Spec.Tracing)
+		}
+
+		if api.Spec.AccessLogging != nil {
+			provider := api.Spec.AccessLogging.Provider
+			if provider == "" {
+				provider = r.accessLogProvider
+			}
+			telemetryBuilder.AccessLogging(provider)
+		}
+
+		for k, v := range r.additionalLabels {
+			telemetryBuilder.Label(k, v)
+		}
+
+		telemetries[key] = telemetryBuilder.Get()
+	}
+
+	return telemetries
+}
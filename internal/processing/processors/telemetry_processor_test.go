@@ -0,0 +1,286 @@
+package processors_test
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
+	"github.com/kyma-project/api-gateway/internal/processing/processors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	telemetryapiv1alpha1 "istio.io/api/telemetry/v1alpha1"
+	apiv1beta1 "istio.io/api/type/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Telemetry Processor", func() {
+	allowRule := func() gatewayv1beta1.Rule {
+		strategies := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "allow",
+				},
+			},
+		}
+		return GetRuleWithServiceFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies, nil)
+	}
+
+	tracedAPIRule := func() *gatewayv1beta1.APIRule {
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		apiRule.Spec.Tracing = &gatewayv1beta1.Tracing{
+			SamplingRate: 25,
+			CustomTags:   map[string]string{"team": "team-a"},
+		}
+		return apiRule
+	}
+
+	ownedTelemetry := func(apiRule *gatewayv1beta1.APIRule) *telemetryv1alpha1.Telemetry {
+		t := &telemetryv1alpha1.Telemetry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "owned-telemetry",
+				Namespace: ApiNamespace,
+				Labels: map[string]string{
+					processing.OwnerLabelv1alpha1: fmt.Sprintf("%s.%s", apiRule.ObjectMeta.Name, apiRule.ObjectMeta.Namespace),
+				},
+			},
+		}
+		t.Spec.Selector = &apiv1beta1.WorkloadSelector{MatchLabels: map[string]string{TestSelectorKey: ServiceName}}
+		return t
+	}
+
+	It("should create telemetry with sampling rate and custom tags for the exposed service", func() {
+		// given
+		apiRule := tracedAPIRule()
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("create"))
+
+		t := result[0].Obj.(*telemetryv1alpha1.Telemetry)
+		Expect(t.GenerateName).To(Equal(ApiName + "-"))
+		Expect(t.Namespace).To(Equal(ApiNamespace))
+		Expect(t.Labels[TestLabelKey]).To(Equal(TestLabelValue))
+		Expect(t.Spec.Selector.MatchLabels).To(Equal(map[string]string{TestSelectorKey: ServiceName}))
+		Expect(t.Spec.Tracing).To(HaveLen(1))
+		Expect(t.Spec.Tracing[0].RandomSamplingPercentage.GetValue()).To(Equal(float64(25)))
+		Expect(t.Spec.Tracing[0].CustomTags).To(HaveKey("team"))
+		Expect(t.Spec.Tracing[0].CustomTags["team"].GetLiteral().GetValue()).To(Equal("team-a"))
+	})
+
+	It("should update existing telemetry of the APIRule", func() {
+		// given
+		apiRule := tracedAPIRule()
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(ownedTelemetry(apiRule)), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("update"))
+		Expect(result[0].Obj.(*telemetryv1alpha1.Telemetry).Spec.Tracing[0].RandomSamplingPercentage.GetValue()).To(Equal(float64(25)))
+	})
+
+	It("should delete existing telemetry when tracing is removed", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(ownedTelemetry(apiRule)), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("delete"))
+	})
+
+	It("should create telemetry with access logging of the default provider for the exposed service", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		apiRule.Spec.AccessLogging = &gatewayv1beta1.AccessLogging{}
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("create"))
+
+		t := result[0].Obj.(*telemetryv1alpha1.Telemetry)
+		Expect(t.Spec.Selector.MatchLabels).To(Equal(map[string]string{TestSelectorKey: ServiceName}))
+		Expect(t.Spec.Tracing).To(BeEmpty())
+		Expect(t.Spec.AccessLogging).To(HaveLen(1))
+		Expect(t.Spec.AccessLogging[0].Providers).To(HaveLen(1))
+		Expect(t.Spec.AccessLogging[0].Providers[0].Name).To(Equal("envoy"))
+	})
+
+	It("should use access log provider of the configuration if the APIRule doesn't define a provider", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		apiRule.Spec.AccessLogging = &gatewayv1beta1.AccessLogging{}
+		config := GetTestConfig()
+		config.AccessLogProvider = "otel"
+		processor := processors.NewTelemetryProcessor(config)
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Obj.(*telemetryv1alpha1.Telemetry).Spec.AccessLogging[0].Providers[0].Name).To(Equal("otel"))
+	})
+
+	It("should use access log provider of the APIRule", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		apiRule.Spec.AccessLogging = &gatewayv1beta1.AccessLogging{Provider: "stackdriver"}
+		config := GetTestConfig()
+		config.AccessLogProvider = "otel"
+		processor := processors.NewTelemetryProcessor(config)
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Obj.(*telemetryv1alpha1.Telemetry).Spec.AccessLogging[0].Providers[0].Name).To(Equal("stackdriver"))
+	})
+
+	It("should configure tracing and access logging in the same telemetry", func() {
+		// given
+		apiRule := tracedAPIRule()
+		apiRule.Spec.AccessLogging = &gatewayv1beta1.AccessLogging{}
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+
+		t := result[0].Obj.(*telemetryv1alpha1.Telemetry)
+		Expect(t.Spec.Tracing).To(HaveLen(1))
+		Expect(t.Spec.AccessLogging).To(HaveLen(1))
+	})
+
+	It("should remove access logging from existing telemetry when access logging is disabled", func() {
+		// given
+		apiRule := tracedAPIRule()
+		existing := ownedTelemetry(apiRule)
+		existing.Spec.AccessLogging = []*telemetryapiv1alpha1.AccessLogging{{Providers: []*telemetryapiv1alpha1.ProviderRef{{Name: "envoy"}}}}
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(existing), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("update"))
+		Expect(result[0].Obj.(*telemetryv1alpha1.Telemetry).Spec.AccessLogging).To(BeEmpty())
+	})
+
+	It("should delete existing telemetry when access logging is disabled and tracing is not configured", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		existing := ownedTelemetry(apiRule)
+		existing.Spec.AccessLogging = []*telemetryapiv1alpha1.AccessLogging{{Providers: []*telemetryapiv1alpha1.ProviderRef{{Name: "envoy"}}}}
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(existing), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("delete"))
+	})
+
+	It("should not create telemetry when neither tracing nor access logging is configured", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should delete existing telemetry of an APIRule whose generation was already reconciled successfully", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		apiRule.Generation = 2
+		apiRule.Status.ObservedGeneration = 2
+		apiRule.Status.APIRuleStatus = &gatewayv1beta1.APIRuleResourceStatus{Code: gatewayv1beta1.StatusOK}
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(ownedTelemetry(apiRule)), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("delete"))
+	})
+
+	It("should not return changes when the Telemetry API is not available and neither tracing nor access logging is configured", func() {
+		// given
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule()})
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClientWithoutTelemetryAPI(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should not return changes and warn when the Telemetry API is not available and tracing is configured", func() {
+		// given
+		apiRule := tracedAPIRule()
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+		client := GetFakeClientWithoutTelemetryAPI()
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+		warnings, warningsErr := processor.EvaluateWarnings(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(BeEmpty())
+		Expect(warningsErr).To(BeNil())
+		Expect(warnings).To(ConsistOf("tracing and access logging are not configured, because the Telemetry API telemetry.istio.io/v1alpha1 is not available in the cluster"))
+	})
+
+	It("should not warn when the Telemetry API is available", func() {
+		// given
+		apiRule := tracedAPIRule()
+		processor := processors.NewTelemetryProcessor(GetTestConfig())
+
+		// when
+		warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(), apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+	})
+})
@@ -2,8 +2,10 @@ package processors
 
 import (
 	"context"
+	"fmt"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +37,42 @@ func (r VirtualServiceProcessor) EvaluateReconciliation(ctx context.Context, cli
 	return []*processing.ObjectChange{changes}, nil
 }
 
+// EvaluateWarnings returns a warning for each exposed service whose name is the DNS name of a single pod of a StatefulSet,
+// e.g. "web-0.nginx", and the Service of the StatefulSet is headless. Requests to it are routed to the pod only and fail
+// while the pod is restarted.
+func (r VirtualServiceProcessor) EvaluateWarnings(ctx context.Context, client ctrlclient.Client, apiRule *gatewayv1beta1.APIRule) ([]string, error) {
+	var warnings []string
+
+	warned := make(map[string]bool)
+	for i := range apiRule.Spec.Rules {
+		rule := &apiRule.Spec.Rules[i]
+		service := apiRule.Spec.Service
+		if rule.Service != nil {
+			service = rule.Service
+		}
+		if service == nil || service.Name == nil || (service.IsExternal != nil && *service.IsExternal) {
+			continue
+		}
+
+		pod, serviceName, ok := helpers.StatefulSetPodSubdomain(*service.Name)
+		namespace := helpers.FindServiceNamespace(apiRule, rule)
+		if !ok || warned[helpers.ServiceKey(namespace, *service.Name)] {
+			continue
+		}
+		warned[helpers.ServiceKey(namespace, *service.Name)] = true
+
+		s, err := helpers.GetService(ctx, client, namespace, serviceName)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil && helpers.IsHeadless(s) {
+			warnings = append(warnings, fmt.Sprintf("Service %s routes to the single pod %s of the headless Service %s/%s, requests fail while the pod is restarted", *service.Name, pod, namespace, serviceName))
+		}
+	}
+
+	return warnings, nil
+}
+
 func (r VirtualServiceProcessor) getDesiredState(api *gatewayv1beta1.APIRule) (*networkingv1beta1.VirtualService, error) {
 	return r.Creator.Create(api)
 }
@@ -57,8 +95,8 @@ func (r VirtualServiceProcessor) getActualState(ctx context.Context, client ctrl
 func (r VirtualServiceProcessor) getObjectChanges(desiredVs *networkingv1beta1.VirtualService, actualVs *networkingv1beta1.VirtualService) *processing.ObjectChange {
 	if actualVs != nil {
 		actualVs.Spec = *desiredVs.Spec.DeepCopy()
-		return processing.NewObjectUpdateAction(actualVs)
+		return processing.NewObjectUpdateAction(processing.RouteClass, actualVs)
 	} else {
-		return processing.NewObjectCreateAction(desiredVs)
+		return processing.NewObjectCreateAction(processing.RouteClass, desiredVs)
 	}
 }
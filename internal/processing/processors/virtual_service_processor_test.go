@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -76,6 +77,57 @@ var _ = Describe("Virtual Service Processor", func() {
 		Expect(result).To(HaveLen(1))
 		Expect(result[0].Action.String()).To(Equal("update"))
 	})
+	When("evaluating warnings", func() {
+		apiRuleFor := func(serviceName string) *gatewayv1beta1.APIRule {
+			strategies := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)})
+			apiRule.Spec.Service = &gatewayv1beta1.Service{Name: &serviceName, Port: &ServicePort}
+			return apiRule
+		}
+
+		serviceWith := func(name string, clusterIP string) *corev1.Service {
+			return &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ApiNamespace},
+				Spec:       corev1.ServiceSpec{ClusterIP: clusterIP},
+			}
+		}
+
+		It("should warn if the service is a single pod of a StatefulSet with headless Service", func() {
+			// given
+			processor := processors.VirtualServiceProcessor{Creator: mockVirtualServiceCreator{}}
+
+			// when
+			warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(serviceWith("nginx", corev1.ClusterIPNone)), apiRuleFor("web-0.nginx"))
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(warnings).To(Equal([]string{fmt.Sprintf("Service web-0.nginx routes to the single pod web-0 of the headless Service %s/nginx, requests fail while the pod is restarted", ApiNamespace)}))
+		})
+
+		It("should not warn if the Service of the pod subdomain is not headless", func() {
+			// given
+			processor := processors.VirtualServiceProcessor{Creator: mockVirtualServiceCreator{}}
+
+			// when
+			warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(serviceWith("nginx", "10.0.0.1")), apiRuleFor("web-0.nginx"))
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should not warn for a regular service", func() {
+			// given
+			processor := processors.VirtualServiceProcessor{Creator: mockVirtualServiceCreator{}}
+
+			// when
+			warnings, err := processor.EvaluateWarnings(context.TODO(), GetFakeClient(serviceWith("nginx", corev1.ClusterIPNone)), apiRuleFor("nginx"))
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
 })
 
 type mockVirtualServiceCreator struct {
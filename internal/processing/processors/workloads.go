@@ -0,0 +1,46 @@
+package processors
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// workloadKey returns the key of the subresource of a workload in the maps of the desired and the actual subresources.
+func workloadKey(namespace string, podLabels map[string]string) string {
+	return fmt.Sprintf("%s/%s", namespace, labels.Set(podLabels).String())
+}
+
+// getWorkloadObjectChanges returns the changes that turn the actual subresources of the workloads into the desired ones.
+// An actual subresource that is still desired is updated with the spec of the desired one. The changes are sorted by the
+// workload key, so that the same state results in the same changes.
+func getWorkloadObjectChanges[T ctrlclient.Object](class processing.SubresourceClass, desired map[string]T, actual map[string]T, updateSpec func(desired T, actual T)) []*processing.ObjectChange {
+	var changes []*processing.ObjectChange
+
+	for _, key := range sortedKeys(desired) {
+		if actualObj, ok := actual[key]; ok {
+			updateSpec(desired[key], actualObj)
+			changes = append(changes, processing.NewObjectUpdateAction(class, actualObj))
+		} else {
+			changes = append(changes, processing.NewObjectCreateAction(class, desired[key]))
+		}
+	}
+
+	for _, key := range sortedKeys(actual) {
+		if _, ok := desired[key]; !ok {
+			changes = append(changes, processing.NewObjectDeleteAction(class, actual[key]))
+		}
+	}
+
+	return changes
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
@@ -3,11 +3,14 @@ package processing
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"runtime/debug"
 
 	"github.com/go-logr/logr"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/validation"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -29,45 +32,191 @@ type ReconciliationProcessor interface {
 	EvaluateReconciliation(context.Context, client.Client, *gatewayv1beta1.APIRule) ([]*ObjectChange, error)
 }
 
-// Reconcile executes the reconciliation of the APIRule using the given reconciliation command.
-func Reconcile(ctx context.Context, client client.Client, log *logr.Logger, cmd ReconciliationCommand, apiRule *gatewayv1beta1.APIRule) ReconciliationStatus {
+// ReconciliationWarningProcessor is implemented by processors that detect problems which don't prevent the reconciliation,
+// but which the user should see in the APIRule status.
+type ReconciliationWarningProcessor interface {
+	// EvaluateWarnings returns the warnings that are added to the description of the APIRule status.
+	EvaluateWarnings(context.Context, client.Client, *gatewayv1beta1.APIRule) ([]string, error)
+}
+
+// ErrSubresourcesNotApplied is returned if the subresources of a class that were written or deleted can't be read back in
+// the expected state yet, so the changes of the next classes have to be applied later.
+var ErrSubresourcesNotApplied = errors.New("changes of APIRule subresources are not applied yet")
+
+// Reconcile executes the reconciliation of the APIRule using the given reconciliation command. The applied changes of a
+// class of subresources are read back with the apiReader before the changes of the next class are applied, so it must not
+// be cached.
+func Reconcile(ctx context.Context, client client.Client, apiReader client.Reader, log *logr.Logger, cmd ReconciliationCommand, apiRule *gatewayv1beta1.APIRule) ReconciliationStatus {
+	status, warnings := reconcile(ctx, client, apiReader, log, cmd, apiRule)
+	return withFaultInjectionWarning(withWarnings(status, warnings), apiRule)
+}
+
+// reconcile returns the status of the reconciliation and the warnings of the processors evaluated until then.
+func reconcile(ctx context.Context, client client.Client, apiReader client.Reader, log *logr.Logger, cmd ReconciliationCommand, apiRule *gatewayv1beta1.APIRule) (ReconciliationStatus, []string) {
 
-	validationFailures, err := cmd.Validate(ctx, client, apiRule)
+	var validationFailures []validation.Failure
+	err := recoverPanic("validation", func() (err error) {
+		validationFailures, err = cmd.Validate(ctx, client, apiRule)
+		return err
+	})
 	if err != nil {
 		// We set the status to skipped because it was not the validation that failed, but an error occurred during validation.
-		log.Error(err, "Error during validation")
+		logError(log, err, "Error during validation")
 		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
 		errorMap := map[ResourceSelector][]error{OnApiRule: {err}}
-		return GetStatusForErrorMap(errorMap, statusBase)
+		return GetStatusForErrorMap(errorMap, statusBase), nil
 	}
 
 	if len(validationFailures) > 0 {
 		failuresJson, _ := json.Marshal(validationFailures)
 		log.Info(fmt.Sprintf(`Validation failure {"controller": "Api", "request": "%s/%s", "failures": %s}`, apiRule.Namespace, apiRule.Name, string(failuresJson)))
 		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
-		return GenerateStatusFromFailures(validationFailures, statusBase)
+		return GenerateStatusFromFailures(validationFailures, statusBase), nil
 	}
 
-	for _, processor := range cmd.GetProcessors() {
+	var warnings []string
+	var writes, deletions []*ObjectChange
+	for i, processor := range cmd.GetProcessors() {
 
-		objectChanges, err := processor.EvaluateReconciliation(ctx, client, apiRule)
+		objectChanges, processorWarnings, err := evaluateProcessor(ctx, client, apiRule, i, processor)
 		if err != nil {
-			log.Error(err, "Error during reconciliation")
+			logError(log, err, "Error during reconciliation")
 			statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
 			errorMap := map[ResourceSelector][]error{OnApiRule: {err}}
-			return GetStatusForErrorMap(errorMap, statusBase)
+			return GetStatusForErrorMap(errorMap, statusBase), warnings
 		}
+		warnings = append(warnings, processorWarnings...)
 
-		errorMap := applyChanges(ctx, client, objectChanges...)
-		if len(errorMap) > 0 {
-			log.Error(err, "Error during applying reconciliation")
-			statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusOK)
-			return GetStatusForErrorMap(errorMap, statusBase)
+		for _, change := range objectChanges {
+			if change.Action == delete {
+				deletions = append(deletions, change)
+			} else {
+				writes = append(writes, change)
+			}
 		}
 	}
 
+	// The changes of all processors are applied class by class, so that authentications are written before the policies
+	// requiring them and policies before the routes they protect. Subresources that are no longer desired are deleted in
+	// the reverse order, the same as on deletion of the APIRule. If the changes of a class aren't visible yet, the status
+	// has an error, so that the remaining changes are applied when the APIRule is requeued.
+	var errorMap map[ResourceSelector][]error
+	err = recoverPanic("applying changes", func() error {
+		errorMap = applyChangesInClassOrder(ctx, client, apiReader, writes, subresourceWriteOrder)
+		if len(errorMap) == 0 {
+			errorMap = applyChangesInClassOrder(ctx, client, apiReader, deletions, subresourceDeletionOrder)
+		}
+		return nil
+	})
+	if err != nil {
+		logError(log, err, "Error during applying reconciliation")
+		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
+		errorMap := map[ResourceSelector][]error{OnApiRule: {err}}
+		return GetStatusForErrorMap(errorMap, statusBase), warnings
+	}
+	if len(errorMap) > 0 {
+		log.Error(err, "Error during applying reconciliation")
+		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusOK)
+		return GetStatusForErrorMap(errorMap, statusBase), warnings
+	}
+
 	statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusOK)
-	return GenerateStatusFromFailures([]validation.Failure{}, statusBase)
+	return GenerateStatusFromFailures([]validation.Failure{}, statusBase), warnings
+}
+
+// PanicError is returned if the validation, a processor or applying the changes panics during the reconciliation, so that
+// a panic while reconciling one APIRule results in an error status instead of a crash of the controller.
+type PanicError struct {
+	// Step is the step of the reconciliation that panicked, e.g. the index and the type of the processor in the processors
+	// of the reconciliation command.
+	Step string
+	// Value is the value the step panicked with.
+	Value interface{}
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Step, e.Value)
+}
+
+// recoverPanic calls fn and returns a panic of fn as PanicError of the step, also if fn panics with nil.
+func recoverPanic(step string, fn func() error) (err error) {
+	completed := false
+	defer func() {
+		if !completed {
+			err = &PanicError{Step: step, Value: recover(), Stack: debug.Stack()}
+		}
+	}()
+
+	err = fn()
+	completed = true
+	return err
+}
+
+// logError logs the error with the stack trace if it is a PanicError.
+func logError(log *logr.Logger, err error, msg string) {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		log.Error(err, "Panic during reconciliation", "stack", string(panicErr.Stack))
+	} else {
+		log.Error(err, msg)
+	}
+}
+
+// evaluateProcessor returns the changes and the warnings of the processor. A panic of the processor is returned as PanicError.
+func evaluateProcessor(ctx context.Context, client client.Client, apiRule *gatewayv1beta1.APIRule, index int, processor ReconciliationProcessor) (changes []*ObjectChange, warnings []string, err error) {
+	err = recoverPanic(fmt.Sprintf("processor %d (%T)", index, processor), func() (err error) {
+		changes, err = processor.EvaluateReconciliation(ctx, client, apiRule)
+		if err == nil {
+			if warningProcessor, ok := processor.(ReconciliationWarningProcessor); ok {
+				warnings, err = warningProcessor.EvaluateWarnings(ctx, client, apiRule)
+			}
+		}
+		return err
+	})
+	return changes, warnings, err
+}
+
+// applyChangesInClassOrder applies the changes of one class after the other in the given order. The changes of the next
+// class are not applied if a change of the previous class failed or can't be read back with the apiReader yet.
+func applyChangesInClassOrder(ctx context.Context, client client.Client, apiReader client.Reader, changes []*ObjectChange, order []SubresourceClass) map[ResourceSelector][]error {
+	for i, class := range order {
+		classChanges := changesOfClass(changes, class)
+		if errorMap := applyChanges(ctx, client, classChanges...); len(errorMap) > 0 {
+			return errorMap
+		}
+		if i < len(order)-1 {
+			if errorMap := verifyChanges(ctx, apiReader, classChanges...); len(errorMap) > 0 {
+				return errorMap
+			}
+		}
+	}
+	return nil
+}
+
+// verifyChanges reads every changed object once with the apiReader and returns ErrSubresourcesNotApplied for the written
+// objects that don't exist and the deleted objects that still exist.
+func verifyChanges(ctx context.Context, apiReader client.Reader, changes ...*ObjectChange) map[ResourceSelector][]error {
+	errorMap := make(map[ResourceSelector][]error)
+	for _, change := range changes {
+		key := client.ObjectKeyFromObject(change.Obj)
+		err := apiReader.Get(ctx, key, change.Obj.DeepCopyObject().(client.Object))
+		switch {
+		case change.Action == delete && err == nil:
+			err = fmt.Errorf("%w: %s still exists", ErrSubresourcesNotApplied, key)
+		case change.Action != delete && apierrs.IsNotFound(err):
+			err = fmt.Errorf("%w: %s doesn't exist", ErrSubresourcesNotApplied, key)
+		case apierrs.IsNotFound(err):
+			err = nil
+		}
+		if err != nil {
+			res := objectToSelector(change.Obj)
+			errorMap[res] = append(errorMap[res], err)
+		}
+	}
+
+	return errorMap
 }
 
 // applyChanges applies the given commands on the cluster
@@ -2,6 +2,7 @@ package processing_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -13,6 +14,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,7 +34,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().Build()
 
 		// when
-		status := processing.Reconcile(context.TODO(), client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -57,7 +60,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().Build()
 
 		// when
-		status := processing.Reconcile(context.TODO(), client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -88,7 +91,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().Build()
 
 		// when
-		status := processing.Reconcile(context.TODO(), client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -102,7 +105,7 @@ var _ = Describe("Reconcile", func() {
 	Context("when VirtualService is missing kind", func() {
 		It("should return api status error when error happened during apply of changes on VS", func() {
 			// given
-			c := []*processing.ObjectChange{processing.NewObjectCreateAction(builders.VirtualService().Get())}
+			c := []*processing.ObjectChange{processing.NewObjectCreateAction(processing.RouteClass, builders.VirtualService().Get())}
 			p := MockReconciliationProcessor{
 				evaluate: func() ([]*processing.ObjectChange, error) {
 					return c, nil
@@ -120,7 +123,7 @@ var _ = Describe("Reconcile", func() {
 			client := fake.NewClientBuilder().Build()
 
 			// when
-			status := processing.Reconcile(context.TODO(), client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+			status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
 
 			// then
 			Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -138,9 +141,9 @@ var _ = Describe("Reconcile", func() {
 		toBeUpdatedVs := builders.VirtualService().Name("toBeUpdated").Get()
 		toBeDeletedVs := builders.VirtualService().Name("toBeDeleted").Get()
 		c := []*processing.ObjectChange{
-			processing.NewObjectCreateAction(builders.VirtualService().Name("test").Get()),
-			processing.NewObjectUpdateAction(toBeUpdatedVs),
-			processing.NewObjectDeleteAction(toBeDeletedVs),
+			processing.NewObjectCreateAction(processing.RouteClass, builders.VirtualService().Name("test").Get()),
+			processing.NewObjectUpdateAction(processing.RouteClass, toBeUpdatedVs),
+			processing.NewObjectDeleteAction(processing.RouteClass, toBeDeletedVs),
 		}
 		p := MockReconciliationProcessor{
 			evaluate: func() ([]*processing.ObjectChange, error) {
@@ -162,7 +165,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(toBeUpdatedVs, toBeDeletedVs).Build()
 
 		// when
-		status := processing.Reconcile(context.TODO(), client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
@@ -173,12 +176,435 @@ var _ = Describe("Reconcile", func() {
 
 	})
 
+	It("should delete subresources after all processors are applied, routes before policies and authentications", func() {
+		// given
+		ra := &securityv1beta1.RequestAuthentication{ObjectMeta: metav1.ObjectMeta{Name: "ra"}}
+		ap := &securityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "ap"}}
+		vs := builders.VirtualService().Name("vs").Get()
+		createdVs := builders.VirtualService().Name("created").Get()
+
+		authProcessor := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectDeleteAction(processing.AuthenticationClass, ra), processing.NewObjectDeleteAction(processing.PolicyClass, ap)}, nil
+			},
+		}
+		vsProcessor := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectDeleteAction(processing.RouteClass, vs), processing.NewObjectCreateAction(processing.RouteClass, createdVs)}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock: func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor {
+				return []processing.ReconciliationProcessor{authProcessor, vsProcessor}
+			},
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(securityv1beta1.AddToScheme(scheme)).To(Succeed())
+		client := &recordingClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(ra, ap, vs).Build()}
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		Expect(client.calls).To(Equal([]string{"create created", "delete vs", "delete ap", "delete ra"}))
+	})
+
+	It("should write authentications before policies and policies before routes", func() {
+		// given
+		ra := &securityv1beta1.RequestAuthentication{ObjectMeta: metav1.ObjectMeta{Name: "ra"}}
+		ap := &securityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "ap"}}
+		vs := builders.VirtualService().Name("vs").Get()
+
+		vsProcessor := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectCreateAction(processing.RouteClass, vs)}, nil
+			},
+		}
+		authProcessor := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectCreateAction(processing.PolicyClass, ap), processing.NewObjectCreateAction(processing.AuthenticationClass, ra)}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock: func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor {
+				return []processing.ReconciliationProcessor{vsProcessor, authProcessor}
+			},
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(securityv1beta1.AddToScheme(scheme)).To(Succeed())
+		client := &recordingClient{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		Expect(client.calls).To(Equal([]string{"create ra", "create ap", "create vs"}))
+	})
+
+	It("should not write routes when writing the authentications failed", func() {
+		// given
+		ra := &securityv1beta1.RequestAuthentication{ObjectMeta: metav1.ObjectMeta{Name: "ra"}}
+		vs := builders.VirtualService().Name("vs").Get()
+
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectCreateAction(processing.RouteClass, vs), processing.NewObjectCreateAction(processing.AuthenticationClass, ra.DeepCopy())}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(securityv1beta1.AddToScheme(scheme)).To(Succeed())
+		// the RequestAuthentication already exists, so creating it fails
+		client := &recordingClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(ra).Build()}
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(client.calls).To(Equal([]string{"create ra"}))
+	})
+
+	It("should requeue and not write policies and routes when verification of the first phase fails", func() {
+		// given
+		ra := &securityv1beta1.RequestAuthentication{ObjectMeta: metav1.ObjectMeta{Name: "ra"}}
+		ap := &securityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "ap"}}
+		vs := builders.VirtualService().Name("vs").Get()
+
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{
+					processing.NewObjectCreateAction(processing.RouteClass, vs),
+					processing.NewObjectCreateAction(processing.PolicyClass, ap),
+					processing.NewObjectCreateAction(processing.AuthenticationClass, ra),
+				}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(securityv1beta1.AddToScheme(scheme)).To(Succeed())
+		client := &recordingClient{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+		// the API server doesn't return the created RequestAuthentication yet
+		apiReader := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, apiReader, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.HasError()).To(BeTrue())
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(client.calls).To(Equal([]string{"create ra"}))
+	})
+
+	It("should not delete authentications when verification of the deletion of the policies fails", func() {
+		// given
+		ra := &securityv1beta1.RequestAuthentication{ObjectMeta: metav1.ObjectMeta{Name: "ra"}}
+		ap := &securityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "ap"}}
+
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{
+					processing.NewObjectDeleteAction(processing.AuthenticationClass, ra),
+					processing.NewObjectDeleteAction(processing.PolicyClass, ap),
+				}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(securityv1beta1.AddToScheme(scheme)).To(Succeed())
+		client := &recordingClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(ra.DeepCopy(), ap.DeepCopy()).Build()}
+		// the API server still returns the deleted AuthorizationPolicy
+		apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ap.DeepCopy()).Build()
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, apiReader, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.HasError()).To(BeTrue())
+		Expect(client.calls).To(Equal([]string{"delete ap"}))
+	})
+
+	It("should return status ok with warning when fault injection is active", func() {
+		// given
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		apiRule := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					{Path: "/headers", Fault: &gatewayv1beta1.Fault{Abort: &gatewayv1beta1.FaultAbort{HttpStatus: 503, Percentage: 10}}},
+					{Path: "/status"},
+				},
+			},
+		}
+		client := fake.NewClientBuilder().Build()
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, apiRule)
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		Expect(status.ApiRuleStatus.Description).To(Equal("Warning: fault injection is active for paths: /headers"))
+		Expect(status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+	})
+
+	It("should keep the error description and append the warning when fault injection is active and an error happened on a subresource", func() {
+		// given
+		toBeUpdatedVs := builders.VirtualService().Name("toBeUpdated").Get()
+		toBeUpdatedVs.Kind = "VirtualService"
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectUpdateAction(processing.RouteClass, toBeUpdatedVs)}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		apiRule := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					{Path: "/headers", Fault: &gatewayv1beta1.Fault{Abort: &gatewayv1beta1.FaultAbort{HttpStatus: 503, Percentage: 10}}},
+				},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, apiRule)
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(status.ApiRuleStatus.Description).To(Equal("Error has happened on subresource VirtualService\nWarning: fault injection is active for paths: /headers"))
+		Expect(status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+	})
+
+	It("should keep the validation error and append the warning when fault injection is active and validation failed", func() {
+		// given
+		failures := []validation.Failure{{
+			AttributePath: "some.path",
+			Message:       "The value is not allowed",
+		}}
+		cmd := MockReconciliationCommand{
+			validateMock: func() ([]validation.Failure, error) { return failures, nil },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusSkipped)
+			},
+		}
+		apiRule := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					{Path: "/headers", Fault: &gatewayv1beta1.Fault{Abort: &gatewayv1beta1.FaultAbort{HttpStatus: 503, Percentage: 10}}},
+				},
+			},
+		}
+		client := fake.NewClientBuilder().Build()
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, apiRule)
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(status.ApiRuleStatus.Description).To(Equal("Validation error: Attribute \"some.path\": The value is not allowed\nWarning: fault injection is active for paths: /headers"))
+	})
+
+	It("should return status ok with the warnings of the processors", func() {
+		// given
+		p := MockReconciliationWarningProcessor{
+			MockReconciliationProcessor: MockReconciliationProcessor{
+				evaluate: func() ([]*processing.ObjectChange, error) {
+					return []*processing.ObjectChange{}, nil
+				},
+			},
+			warnings: []string{"NetworkPolicy default/user-np selects the same pods as the NetworkPolicy of the APIRule"},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		client := fake.NewClientBuilder().Build()
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		Expect(status.ApiRuleStatus.Description).To(Equal("Warning: NetworkPolicy default/user-np selects the same pods as the NetworkPolicy of the APIRule"))
+	})
+
+	DescribeTable("should return status error and not evaluate the next processors when a processor panics",
+		func(panicValue interface{}) {
+			// given
+			panicking := MockReconciliationProcessor{
+				evaluate: func() ([]*processing.ObjectChange, error) {
+					panic(panicValue)
+				},
+			}
+			nextEvaluated := false
+			next := MockReconciliationProcessor{
+				evaluate: func() ([]*processing.ObjectChange, error) {
+					nextEvaluated = true
+					return []*processing.ObjectChange{}, nil
+				},
+			}
+
+			cmd := MockReconciliationCommand{
+				validateMock: func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+				processorMocks: func() []processing.ReconciliationProcessor {
+					return []processing.ReconciliationProcessor{panicking, next}
+				},
+				getStatusBaseMock: func() processing.ReconciliationStatus {
+					return mockStatusBase(gatewayv1beta1.StatusSkipped)
+				},
+			}
+
+			var loggedErrors []error
+			logger := errorRecordingLogger(&loggedErrors)
+
+			// when
+			status := processing.Reconcile(context.TODO(), fake.NewClientBuilder().Build(), fake.NewClientBuilder().Build(), logger, cmd, &gatewayv1beta1.APIRule{})
+
+			// then
+			Expect(status.HasError()).To(BeTrue())
+			Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+			Expect(status.ApiRuleStatus.Description).To(HavePrefix("processor 0 (processing_test.MockReconciliationProcessor) panicked: "))
+			Expect(nextEvaluated).To(BeFalse())
+			expectPanicError(loggedErrors, panicValue)
+		},
+		Entry("with a string", "something went wrong"),
+		Entry("with an error", fmt.Errorf("something went wrong")),
+		Entry("with nil", nil),
+	)
+
+	It("should return status error and not evaluate the processors when the validation panics", func() {
+		// given
+		evaluated := false
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				evaluated = true
+				return []*processing.ObjectChange{}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { panic("something went wrong") },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusSkipped)
+			},
+		}
+
+		var loggedErrors []error
+		logger := errorRecordingLogger(&loggedErrors)
+
+		// when
+		status := processing.Reconcile(context.TODO(), fake.NewClientBuilder().Build(), fake.NewClientBuilder().Build(), logger, cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(status.ApiRuleStatus.Description).To(Equal("validation panicked: something went wrong"))
+		Expect(evaluated).To(BeFalse())
+		expectPanicError(loggedErrors, "something went wrong")
+	})
+
+	It("should return status error when applying the changes panics", func() {
+		// given
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectCreateAction(processing.RouteClass, builders.VirtualService().Name("vs").Get())}, nil
+			},
+		}
+
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() processing.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusSkipped)
+			},
+		}
+
+		client := panickingClient{Client: fake.NewClientBuilder().Build()}
+		var loggedErrors []error
+		logger := errorRecordingLogger(&loggedErrors)
+
+		// when
+		status := processing.Reconcile(context.TODO(), client, client, logger, cmd, &gatewayv1beta1.APIRule{})
+
+		// then
+		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(status.ApiRuleStatus.Description).To(Equal("applying changes panicked: something went wrong"))
+		expectPanicError(loggedErrors, "something went wrong")
+	})
+
 	It("should return status error on APIRule and VS for update on non existing VS", func() {
 		// give
 		toBeUpdatedVs := builders.VirtualService().Name("toBeUpdated").Get()
 		toBeUpdatedVs.Kind = "VirtualService"
 		c := []*processing.ObjectChange{
-			processing.NewObjectUpdateAction(toBeUpdatedVs),
+			processing.NewObjectUpdateAction(processing.RouteClass, toBeUpdatedVs),
 		}
 		p := MockReconciliationProcessor{
 			evaluate: func() ([]*processing.ObjectChange, error) {
@@ -200,7 +626,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 		// when
-		status := processing.Reconcile(context.TODO(), client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
+		status := processing.Reconcile(context.TODO(), client, client, testLogger(), cmd, &gatewayv1beta1.APIRule{})
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -235,10 +661,77 @@ func (r MockReconciliationProcessor) EvaluateReconciliation(_ context.Context, _
 	return r.evaluate()
 }
 
+type MockReconciliationWarningProcessor struct {
+	MockReconciliationProcessor
+	warnings []string
+}
+
+func (r MockReconciliationWarningProcessor) EvaluateWarnings(_ context.Context, _ client.Client, _ *gatewayv1beta1.APIRule) ([]string, error) {
+	return r.warnings, nil
+}
+
 func (c MockReconciliationCommand) GetStatusBase(_ gatewayv1beta1.StatusCode) processing.ReconciliationStatus {
 	return c.getStatusBaseMock()
 }
 
+// recordingClient records the create and delete calls in the order they are made.
+type recordingClient struct {
+	client.Client
+	calls []string
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.calls = append(c.calls, "create "+obj.GetName())
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.calls = append(c.calls, "delete "+obj.GetName())
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// panickingClient panics on create.
+type panickingClient struct {
+	client.Client
+}
+
+func (c panickingClient) Create(_ context.Context, _ client.Object, _ ...client.CreateOption) error {
+	panic("something went wrong")
+}
+
+// errorRecordingSink records the errors that are logged.
+type errorRecordingSink struct {
+	errors *[]error
+}
+
+func (s errorRecordingSink) Init(logr.RuntimeInfo)                  {}
+func (s errorRecordingSink) Enabled(int) bool                       { return true }
+func (s errorRecordingSink) Info(int, string, ...interface{})       {}
+func (s errorRecordingSink) WithValues(...interface{}) logr.LogSink { return s }
+func (s errorRecordingSink) WithName(string) logr.LogSink           { return s }
+
+func (s errorRecordingSink) Error(err error, _ string, _ ...interface{}) {
+	*s.errors = append(*s.errors, err)
+}
+
+func errorRecordingLogger(errors *[]error) *logr.Logger {
+	logger := logr.New(errorRecordingSink{errors: errors})
+	return &logger
+}
+
+// expectPanicError expects that a single error was logged, which is a PanicError with the value and a stack trace.
+func expectPanicError(loggedErrors []error, value interface{}) {
+	Expect(loggedErrors).To(HaveLen(1))
+	var panicErr *processing.PanicError
+	Expect(errors.As(loggedErrors[0], &panicErr)).To(BeTrue())
+	if value == nil {
+		Expect(panicErr.Value).To(BeNil())
+	} else {
+		Expect(panicErr.Value).To(Equal(value))
+	}
+	Expect(panicErr.Stack).NotTo(BeEmpty())
+}
+
 func testLogger() *logr.Logger {
 	logger := ctrl.Log.WithName("test")
 	return &logger
@@ -2,8 +2,10 @@ package processing
 
 import (
 	"fmt"
+	"strings"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/validation"
 )
 
@@ -138,6 +140,54 @@ func generateValidationDescription(failures []validation.Failure) string {
 	return description
 }
 
+// WithRejectedConfigWarning appends a warning to the APIRule status if the config of the ConfigMap was rejected, so that
+// it is visible on the APIRule that it is reconciled with the last valid config.
+func WithRejectedConfigWarning(status ReconciliationStatus, failures []validation.Failure) ReconciliationStatus {
+	if len(failures) == 0 {
+		return status
+	}
+
+	warning := fmt.Sprintf("the config of ConfigMap %s/%s was rejected, the last valid config is used. %s", helpers.CM_NS, helpers.CM_NAME, generateValidationDescription(failures))
+	return withWarnings(status, []string{warning})
+}
+
+// withWarnings appends the warnings of the processors to the description of the APIRule status.
+func withWarnings(status ReconciliationStatus, warnings []string) ReconciliationStatus {
+	for _, warning := range warnings {
+		appendToDescription(status, fmt.Sprintf("Warning: %s", warning))
+	}
+	return status
+}
+
+// withFaultInjectionWarning adds a warning to the APIRule status if faults are injected into the traffic of any rule,
+// so that an active fault injection is always visible on the APIRule. An existing description is kept and the warning is
+// appended to it.
+func withFaultInjectionWarning(status ReconciliationStatus, api *gatewayv1beta1.APIRule) ReconciliationStatus {
+	var paths []string
+	for _, rule := range api.Spec.Rules {
+		if rule.Fault != nil {
+			paths = append(paths, rule.Path)
+		}
+	}
+
+	if len(paths) > 0 {
+		appendToDescription(status, fmt.Sprintf("Warning: fault injection is active for paths: %s", strings.Join(paths, ", ")))
+	}
+	return status
+}
+
+func appendToDescription(status ReconciliationStatus, text string) {
+	if status.ApiRuleStatus == nil {
+		return
+	}
+
+	if status.ApiRuleStatus.Description == "" {
+		status.ApiRuleStatus.Description = text
+	} else {
+		status.ApiRuleStatus.Description = fmt.Sprintf("%s\n%s", status.ApiRuleStatus.Description, text)
+	}
+}
+
 func toStatus(c gatewayv1beta1.StatusCode, desc string) *gatewayv1beta1.APIRuleResourceStatus {
 	return &gatewayv1beta1.APIRuleResourceStatus{
 		Code:        c,
@@ -0,0 +1,85 @@
+package processing
+
+import (
+	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SubresourceClass defines when a subresource of an APIRule is written and deleted relative to the other subresources.
+type SubresourceClass int
+
+const (
+	// RouteClass contains the subresources that expose the workload. They are written last and deleted first, so that no
+	// route is exposed without the policies protecting it.
+	RouteClass SubresourceClass = iota
+	// PolicyClass contains the subresources that restrict the access to the workload.
+	PolicyClass
+	// AuthenticationClass contains the subresources that validate the tokens the policies require. They are written first
+	// and deleted last, so that no policy rejects requests because the token can't be validated yet.
+	AuthenticationClass
+)
+
+// subresourceWriteOrder contains the classes in the order their subresources are created and updated.
+var subresourceWriteOrder = []SubresourceClass{AuthenticationClass, PolicyClass, RouteClass}
+
+// subresourceDeletionOrder contains the classes in the order their subresources are deleted.
+var subresourceDeletionOrder = []SubresourceClass{RouteClass, PolicyClass, AuthenticationClass}
+
+type subresource struct {
+	class   SubresourceClass
+	newList func() client.ObjectList
+}
+
+// subresources declares the class of every kind of subresource an APIRule can own, so that the cleaner can delete them
+// class by class.
+var subresources = []subresource{
+	{
+		class:   RouteClass,
+		newList: func() client.ObjectList { return &networkingv1beta1.VirtualServiceList{} },
+	},
+	{
+		class:   PolicyClass,
+		newList: func() client.ObjectList { return &securityv1beta1.AuthorizationPolicyList{} },
+	},
+	{
+		class:   PolicyClass,
+		newList: func() client.ObjectList { return &rulev1alpha1.RuleList{} },
+	},
+	{
+		class:   PolicyClass,
+		newList: func() client.ObjectList { return &networkingv1.NetworkPolicyList{} },
+	},
+	{
+		class:   PolicyClass,
+		newList: func() client.ObjectList { return &telemetryv1alpha1.TelemetryList{} },
+	},
+	{
+		class:   AuthenticationClass,
+		newList: func() client.ObjectList { return &securityv1beta1.RequestAuthenticationList{} },
+	},
+}
+
+// changesOfClass returns the changes of the objects of the given class.
+func changesOfClass(changes []*ObjectChange, class SubresourceClass) []*ObjectChange {
+	var classChanges []*ObjectChange
+	for _, change := range changes {
+		if change.Class == class {
+			classChanges = append(classChanges, change)
+		}
+	}
+	return classChanges
+}
+
+func subresourcesOfClass(class SubresourceClass) []subresource {
+	var classSubresources []subresource
+	for _, s := range subresources {
+		if s.class == class {
+			classSubresources = append(classSubresources, s)
+		}
+	}
+	return classSubresources
+}
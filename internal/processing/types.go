@@ -1,6 +1,7 @@
 package processing
 
 import (
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	v1beta1 "istio.io/api/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,26 +29,32 @@ func (s Action) String() string {
 type ObjectChange struct {
 	Action Action
 	Obj    client.Object
+	// Class is the class of the changed object, set by the processor that evaluated the change. The changes are applied
+	// class by class.
+	Class SubresourceClass
 }
 
-func NewObjectCreateAction(obj client.Object) *ObjectChange {
+func NewObjectCreateAction(class SubresourceClass, obj client.Object) *ObjectChange {
 	return &ObjectChange{
 		Action: create,
 		Obj:    obj,
+		Class:  class,
 	}
 }
 
-func NewObjectUpdateAction(obj client.Object) *ObjectChange {
+func NewObjectUpdateAction(class SubresourceClass, obj client.Object) *ObjectChange {
 	return &ObjectChange{
 		Action: update,
 		Obj:    obj,
+		Class:  class,
 	}
 }
 
-func NewObjectDeleteAction(obj client.Object) *ObjectChange {
+func NewObjectDeleteAction(class SubresourceClass, obj client.Object) *ObjectChange {
 	return &ObjectChange{
 		Action: delete,
 		Obj:    obj,
+		Class:  class,
 	}
 }
 
@@ -59,13 +66,20 @@ type CorsConfig struct {
 }
 
 type ReconciliationConfig struct {
-	OathkeeperSvc       string
-	OathkeeperSvcPort   uint32
-	CorsConfig          *CorsConfig
-	AdditionalLabels    map[string]string
-	DefaultDomainName   string
-	ServiceBlockList    map[string][]string
-	DomainAllowList     []string
-	HostBlockList       []string
-	HTTPTimeoutDuration int
+	OathkeeperSvc         string
+	OathkeeperSvcPort     uint32
+	CorsConfig            *CorsConfig
+	AdditionalLabels      map[string]string
+	DefaultDomainName     string
+	ServiceBlockList      map[string][]string
+	DomainAllowList       []string
+	HostBlockList         []string
+	HTTPTimeoutDuration   int
+	ScopeClaims           []string
+	FaultInjectionEnabled bool
+	NetworkPolicyEnabled  bool
+	Quota                 helpers.QuotaConfig
+	Exposure              helpers.ExposureConfig
+	MaxRetryAttempts      int32
+	AccessLogProvider     string
 }
@@ -0,0 +1,68 @@
+package processing
+
+import (
+	"context"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewAPIRuleValidator returns a validator with the configuration of the reconciliation, the APIRules in the cluster and the
+// quota of the namespace of the APIRule. The validators that depend on the JWT handler have to be set by the caller.
+func NewAPIRuleValidator(ctx context.Context, client client.Client, config ReconciliationConfig, apiRule *gatewayv1beta1.APIRule) (*validation.APIRuleValidator, error) {
+	var apiRuleList gatewayv1beta1.APIRuleList
+	if err := client.List(ctx, &apiRuleList); err != nil {
+		return nil, err
+	}
+
+	quota, err := helpers.NamespaceQuota(ctx, client, config.Quota, apiRule.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validation.APIRuleValidator{
+		ServiceBlockList:      config.ServiceBlockList,
+		DomainAllowList:       config.DomainAllowList,
+		HostBlockList:         config.HostBlockList,
+		DefaultDomainName:     config.DefaultDomainName,
+		FaultInjectionEnabled: config.FaultInjectionEnabled,
+		Quota:                 quota,
+		Exposure:              config.Exposure,
+		HTTPTimeoutDuration:   config.HTTPTimeoutDuration,
+		MaxRetryAttempts:      config.MaxRetryAttempts,
+		APIRules:              apiRuleList.Items,
+	}, nil
+}
+
+// ValidateAPIRule validates the APIRule with the validator against the Virtual Services, the exposed Services and, if the
+// APIRule configures tracing or access logging, the Telemetries in the cluster.
+func ValidateAPIRule(ctx context.Context, client client.Client, validator *validation.APIRuleValidator, apiRule *gatewayv1beta1.APIRule) ([]validation.Failure, error) {
+	var vsList networkingv1beta1.VirtualServiceList
+	if err := client.List(ctx, &vsList); err != nil {
+		return make([]validation.Failure, 0), err
+	}
+
+	services, err := helpers.GetExposedServices(ctx, client, apiRule)
+	if err != nil {
+		return make([]validation.Failure, 0), err
+	}
+
+	failures := append(validator.Validate(apiRule, vsList), validator.ValidateHeadlessServices(apiRule, services)...)
+	if apiRule.Spec.Tracing == nil && apiRule.Spec.AccessLogging == nil {
+		return failures, nil
+	}
+
+	var telemetryList telemetryv1alpha1.TelemetryList
+	if err := client.List(ctx, &telemetryList); meta.IsNoMatchError(err) {
+		// Without the Telemetry API in the cluster no other APIRule can have a Telemetry for the workloads.
+		return failures, nil
+	} else if err != nil {
+		return make([]validation.Failure, 0), err
+	}
+	return append(failures, validator.ValidateTelemetries(apiRule, telemetryList)...), nil
+}
@@ -1,8 +1,34 @@
 package ory
 
+import gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+
 // JWTAccStrConfig is used to deserialize jwt accessStrategy configuration for the validation purposes
 type JWTAccStrConfig struct {
-	TrustedIssuers []string `json:"trusted_issuers,omitempty"`
-	JWKSUrls       []string `json:"jwks_urls,omitempty"`
-	RequiredScopes []string `json:"required_scopes,omitempty"`
+	TrustedIssuers []string   `json:"trusted_issuers,omitempty"`
+	JWKSUrls       []string   `json:"jwks_urls,omitempty"`
+	RequiredScopes []string   `json:"required_scopes,omitempty"`
+	TokenFrom      *TokenFrom `json:"token_from,omitempty"`
+	// FromHeaders and FromParams define the location of the token with the same fields as the authentications of the
+	// Istio handler. They are mapped to TokenFrom in the Oathkeeper rule.
+	FromHeaders []*gatewayv1beta1.JwtHeader `json:"fromHeaders,omitempty"`
+	FromParams  []string                    `json:"fromParams,omitempty"`
+}
+
+// TokenFrom defines the location from which the oathkeeper jwt authenticator takes the token. Only one of the fields can be set.
+type TokenFrom struct {
+	Header         string `json:"header,omitempty"`
+	QueryParameter string `json:"query_parameter,omitempty"`
+	Cookie         string `json:"cookie,omitempty"`
+}
+
+// TokenFromSources returns the TokenFrom for the first header of FromHeaders or the first parameter of FromParams, or nil
+// if neither is set.
+func (c *JWTAccStrConfig) TokenFromSources() *TokenFrom {
+	if len(c.FromHeaders) > 0 {
+		return &TokenFrom{Header: c.FromHeaders[0].Name}
+	}
+	if len(c.FromParams) > 0 {
+		return &TokenFrom{QueryParameter: c.FromParams[0]}
+	}
+	return nil
 }
@@ -2,18 +2,33 @@ package validation
 
 import (
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/api-gateway/internal/builders"
 	"github.com/kyma-project/api-gateway/internal/helpers"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 
 	gatewayv1alpha1 "github.com/kyma-project/api-gateway/api/v1alpha1"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"golang.org/x/exp/maps"
 	apiv1beta1 "istio.io/api/type/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/strings/slices"
 )
 
+// supportedMethods are the HTTP methods defined in RFC 9110 and RFC 5789. Istio and Oathkeeper match methods case-sensitive,
+// so the methods of the rules are upper cased before they are compared.
+var supportedMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
 // Validators for AccessStrategies
 var vldNoConfig = &noConfigAccStrValidator{}
 var vldDummy = &dummyHandlerValidator{}
@@ -49,6 +64,14 @@ type APIRuleValidator struct {
 	DomainAllowList           []string
 	HostBlockList             []string
 	DefaultDomainName         string
+	FaultInjectionEnabled     bool
+	Quota                     helpers.QuotaConfig
+	Exposure                  helpers.ExposureConfig
+	HTTPTimeoutDuration       int
+	MaxRetryAttempts          int32
+	// APIRules are the APIRules of the cluster. Of two APIRules with the same host, the one that has a Virtual Service
+	// keeps the host. If neither has one yet, the older one keeps it.
+	APIRules []gatewayv1beta1.APIRule
 }
 
 // Failure carries validation failures for a single attribute of an object.
@@ -71,6 +94,18 @@ func (v *APIRuleValidator) Validate(api *gatewayv1beta1.APIRule, vsList networki
 	res = append(res, v.validateGateway(".spec.gateway", api.Spec.Gateway)...)
 	//Validate Rules
 	res = append(res, v.validateRules(".spec.rules", api.Spec.Service == nil, api)...)
+	//Validate Tracing
+	if api.Spec.Tracing != nil {
+		res = append(res, v.validateTracing(".spec.tracing", api.Spec.Tracing)...)
+	}
+	//Validate timeout and retries
+	res = append(res, v.validateTimeoutAndRetries(".spec", api, nil)...)
+	//Validate CORS policy
+	if api.Spec.CorsPolicy != nil {
+		res = append(res, v.validateCorsPolicy(".spec.corsPolicy", api.Spec.CorsPolicy)...)
+	}
+	//Validate Quota
+	res = append(res, v.validateQuota(".spec", vsList, api)...)
 
 	return res
 }
@@ -88,6 +123,35 @@ func (v *APIRuleValidator) ValidateConfig(config *helpers.Config) []Failure {
 				Message: fmt.Sprintf("Unsupported JWT Handler: %s", config.JWTHandler),
 			})
 		}
+		for i, claim := range config.ScopeClaims {
+			if claim == "" {
+				problems = append(problems, Failure{
+					AttributePath: fmt.Sprintf("scopeClaims[%d]", i),
+					Message:       "Scope claim name cannot be empty",
+				})
+			}
+		}
+		if config.TrailingSlashPolicy != "" && !slices.Contains([]string{helpers.TRAILING_SLASH_KEEP, helpers.TRAILING_SLASH_STRIP, helpers.TRAILING_SLASH_BOTH}, config.TrailingSlashPolicy) {
+			problems = append(problems, Failure{
+				AttributePath: "trailingSlashPolicy",
+				Message:       fmt.Sprintf("Unsupported trailing slash policy: %s", config.TrailingSlashPolicy),
+			})
+		}
+		presetNames := make([]string, 0, len(config.AccessStrategyPresets))
+		for name := range config.AccessStrategyPresets {
+			presetNames = append(presetNames, name)
+		}
+		sort.Strings(presetNames)
+		for _, name := range presetNames {
+			for i, accessStrategy := range config.AccessStrategyPresets[name] {
+				if accessStrategy.Handler == "" {
+					problems = append(problems, Failure{
+						AttributePath: fmt.Sprintf("accessStrategyPresets.%s[%d].handler", name, i),
+						Message:       "Handler of the preset access strategy cannot be empty",
+					})
+				}
+			}
+		}
 	}
 
 	return problems
@@ -132,6 +196,13 @@ func (v *APIRuleValidator) validateHost(attributePath string, vsList networkingv
 		}
 	}
 
+	if len(v.Exposure.AllowedHostSuffixes) > 0 && !hasAllowedSuffix(v.Exposure.AllowedHostSuffixes, host) {
+		problems = append(problems, Failure{
+			AttributePath: attributePath,
+			Message:       fmt.Sprintf("Host %s doesn't end with an allowed host suffix", host),
+		})
+	}
+
 	for _, blockedHost := range v.HostBlockList {
 		host := *api.Spec.Host
 		if blockedHost == host {
@@ -143,11 +214,48 @@ func (v *APIRuleValidator) validateHost(attributePath string, vsList networkingv
 		}
 	}
 
+	ownsHost := false
+	occupyingOwners := make(map[string]bool)
 	for _, vs := range vsList.Items {
-		if occupiesHost(vs, host) && !ownedBy(vs, api) {
+		// A Virtual Service that is being deleted releases the host, so that an APIRule replacing it is not blocked
+		if vs.DeletionTimestamp != nil || !occupiesHost(vs, host) {
+			continue
+		}
+		if ownedBy(vs, api) {
+			ownsHost = true
+			continue
+		}
+		owner := vs.GetLabels()[fmt.Sprintf("%s.%s", "apirule", gatewayv1alpha1.GroupVersion.String())]
+		// The Virtual Service of an APIRule that is being deleted doesn't keep the host
+		if ownerAPIRule := v.findAPIRule(owner); ownerAPIRule != nil && ownerAPIRule.DeletionTimestamp != nil {
+			continue
+		}
+		occupyingOwners[owner] = true
+		problems = append(problems, Failure{
+			AttributePath: attributePath,
+			Message:       occupiedHostMessage(vs),
+		})
+	}
+
+	// The Virtual Service keeps the host, the creation time only decides between APIRules that don't have one yet
+	if ownsHost {
+		return problems
+	}
+	for i := range v.APIRules {
+		other := &v.APIRules[i]
+		owner := fmt.Sprintf("%s.%s", other.Name, other.Namespace)
+		if other.DeletionTimestamp != nil || other.Spec.Host == nil || occupyingOwners[owner] ||
+			(other.Name == api.Name && other.Namespace == api.Namespace) {
+			continue
+		}
+		otherHost := *other.Spec.Host
+		if !helpers.HostIncludesDomain(otherHost) {
+			otherHost = helpers.GetHostWithDefaultDomain(otherHost, v.DefaultDomainName)
+		}
+		if otherHost == host && isOlder(other, api) {
 			problems = append(problems, Failure{
 				AttributePath: attributePath,
-				Message:       "This host is occupied by another Virtual Service",
+				Message:       fmt.Sprintf("This host is already used by the older APIRule %s", owner),
 			})
 		}
 	}
@@ -155,6 +263,39 @@ func (v *APIRuleValidator) validateHost(attributePath string, vsList networkingv
 	return problems
 }
 
+// findAPIRule returns the APIRule with the given owner label value "<name>.<namespace>", or nil if it doesn't exist.
+func (v *APIRuleValidator) findAPIRule(owner string) *gatewayv1beta1.APIRule {
+	name, namespace, ok := parseOwner(owner)
+	if !ok {
+		return nil
+	}
+	for i := range v.APIRules {
+		if v.APIRules[i].Name == name && v.APIRules[i].Namespace == namespace {
+			return &v.APIRules[i]
+		}
+	}
+	return nil
+}
+
+// parseOwner returns the name and the namespace of the APIRule from the owner label value "<name>.<namespace>". The name
+// of an APIRule can contain dots, but the namespace can't, so the value is split at the last dot.
+func parseOwner(owner string) (name string, namespace string, ok bool) {
+	i := strings.LastIndex(owner, ".")
+	if i <= 0 || i == len(owner)-1 {
+		return "", "", false
+	}
+	return owner[:i], owner[i+1:], true
+}
+
+// isOlder returns true if the APIRule a was created before b. APIRules created in the same second are ordered by
+// namespace and name, so that exactly one of them keeps the host.
+func isOlder(a *gatewayv1beta1.APIRule, b *gatewayv1beta1.APIRule) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
+
 func (v *APIRuleValidator) validateService(attributePath string, api *gatewayv1beta1.APIRule) []Failure {
 	var problems []Failure
 
@@ -169,6 +310,7 @@ func (v *APIRuleValidator) validateService(attributePath string, api *gatewayv1b
 			}
 		}
 	}
+	problems = append(problems, v.validateExposure(attributePath, api, api.Spec.Service, helpers.FindServiceNamespace(api, nil))...)
 	return problems
 }
 
@@ -210,10 +352,19 @@ func (v *APIRuleValidator) validateRules(attributePath string, checkForService b
 					}
 				}
 			}
+			problems = append(problems, v.validateExposure(attributePathWithRuleIndex+".service", api, r.Service, helpers.FindServiceNamespace(api, &r))...)
 		} else if api.Spec.Service != nil {
 			problems = append(problems, v.validateAccessStrategies(attributePathWithRuleIndex+".accessStrategies", r.AccessStrategies, builders.SelectorFromService(api.Spec.Service), helpers.FindServiceNamespace(api, &r))...)
 		}
 
+		if r.Fault != nil {
+			problems = append(problems, v.validateFault(attributePathWithRuleIndex+".fault", r.Fault)...)
+		}
+
+		if r.Timeout != nil || r.Retries != nil {
+			problems = append(problems, v.validateTimeoutAndRetries(attributePathWithRuleIndex, api, &r)...)
+		}
+
 		if v.MutatorsValidator != nil {
 			mutatorFailures := v.MutatorsValidator.Validate(attributePathWithRuleIndex, r)
 			problems = append(problems, mutatorFailures...)
@@ -228,8 +379,337 @@ func (v *APIRuleValidator) validateRules(attributePath string, checkForService b
 	return problems
 }
 
+func (v *APIRuleValidator) validateTracing(attributePath string, tracing *gatewayv1beta1.Tracing) []Failure {
+	var problems []Failure
+
+	if !isValidPercentage(tracing.SamplingRate) {
+		problems = append(problems, Failure{AttributePath: attributePath + ".samplingRate", Message: "value must be between 0 and 100"})
+	}
+
+	for name := range tracing.CustomTags {
+		if name == "" {
+			problems = append(problems, Failure{AttributePath: attributePath + ".customTags", Message: "tag name must not be empty"})
+		}
+	}
+
+	return problems
+}
+
+// ValidateHeadlessServices checks the ports of the exposed headless Services. Requests to a headless Service are sent to
+// the IPs of its pods, so the port of the APIRule must be a port of the Service that targets the same numeric container
+// port.
+func (v *APIRuleValidator) ValidateHeadlessServices(api *gatewayv1beta1.APIRule, services map[string]*corev1.Service) []Failure {
+	var problems []Failure
+
+	checked := make(map[string]bool)
+	for i, rule := range api.Spec.Rules {
+		attributePath := fmt.Sprintf(".spec.rules[%d].service", i)
+		service := rule.Service
+		if service == nil {
+			attributePath = ".spec.service"
+			service = api.Spec.Service
+		}
+		if service == nil || service.Name == nil || service.Port == nil || checked[attributePath] {
+			continue
+		}
+		checked[attributePath] = true
+
+		key := helpers.ServiceKey(helpers.FindServiceNamespace(api, &rule), *service.Name)
+		s, ok := services[key]
+		if !ok || !helpers.IsHeadless(s) {
+			continue
+		}
+
+		var servicePort *corev1.ServicePort
+		for j := range s.Spec.Ports {
+			if uint32(s.Spec.Ports[j].Port) == *service.Port {
+				servicePort = &s.Spec.Ports[j]
+			}
+		}
+		if servicePort == nil {
+			problems = append(problems, Failure{
+				AttributePath: attributePath + ".port",
+				Message:       fmt.Sprintf("Port %d is not a port of the headless Service %s", *service.Port, key),
+			})
+			continue
+		}
+
+		targetPort := servicePort.TargetPort
+		if targetPort.Type == intstr.String || (targetPort.IntVal != 0 && uint32(targetPort.IntVal) != *service.Port) {
+			problems = append(problems, Failure{
+				AttributePath: attributePath + ".port",
+				Message:       fmt.Sprintf("Port %d of the headless Service %s must target the same numeric container port, but targets %s", *service.Port, key, targetPort.String()),
+			})
+		}
+	}
+
+	return problems
+}
+
+// ValidateTelemetries checks that no workload the APIRule configures tracing or access logging for has a Telemetry of
+// another APIRule. Istio applies only one Telemetry with a selector to a workload, so the second one would be ignored.
+func (v *APIRuleValidator) ValidateTelemetries(api *gatewayv1beta1.APIRule, telemetryList telemetryv1alpha1.TelemetryList) []Failure {
+	var problems []Failure
+	if api.Spec.Tracing == nil && api.Spec.AccessLogging == nil {
+		return problems
+	}
+
+	checked := make(map[string]bool)
+	for i, rule := range api.Spec.Rules {
+		attributePath := fmt.Sprintf(".spec.rules[%d].service", i)
+		service := rule.Service
+		if service == nil {
+			attributePath = ".spec.service"
+			service = api.Spec.Service
+		}
+		if service == nil || checked[attributePath] {
+			continue
+		}
+		checked[attributePath] = true
+
+		namespace := helpers.FindServiceNamespace(api, &rule)
+		selector := builders.SelectorFromService(service)
+		for _, telemetry := range telemetryList.Items {
+			if telemetry.DeletionTimestamp != nil || telemetry.Namespace != namespace || telemetry.Spec.Selector == nil || ownedBy(telemetry, api) {
+				continue
+			}
+			owner, ok := telemetry.GetLabels()[fmt.Sprintf("%s.%s", "apirule", gatewayv1alpha1.GroupVersion.String())]
+			if !ok || !maps.Equal(telemetry.Spec.Selector.MatchLabels, selector.MatchLabels) {
+				continue
+			}
+			problems = append(problems, Failure{
+				AttributePath: attributePath,
+				Message:       fmt.Sprintf("The workload already has a Telemetry for tracing or access logging owned by APIRule %s", owner),
+			})
+			break
+		}
+	}
+
+	return problems
+}
+
+// validateTimeoutAndRetries checks the timeout and retries defined on the given level. The rule is nil for the spec level.
+// The per try timeout is compared to the timeout that applies to the rule, so that a rule with a shorter timeout can't
+// inherit retries from the spec level that never finish in time.
+func (v *APIRuleValidator) validateTimeoutAndRetries(attributePath string, api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule) []Failure {
+	var problems []Failure
+
+	timeout, retries := api.Spec.Timeout, api.Spec.Retries
+	if rule != nil {
+		timeout, retries = rule.Timeout, helpers.FindRetries(api, rule)
+	}
+
+	if timeout != nil && timeout.Duration <= 0 {
+		problems = append(problems, Failure{AttributePath: attributePath + ".timeout", Message: "value must be greater than 0"})
+	}
+
+	if retries == nil {
+		return problems
+	}
+
+	// Inherited retries are validated on the spec level already, so only the per try timeout is checked again
+	if rule == nil || rule.Retries != nil {
+		if retries.Attempts < 0 {
+			problems = append(problems, Failure{AttributePath: attributePath + ".retries.attempts", Message: "value must not be negative"})
+		} else if v.MaxRetryAttempts > 0 && retries.Attempts > v.MaxRetryAttempts {
+			problems = append(problems, Failure{AttributePath: attributePath + ".retries.attempts", Message: fmt.Sprintf("value must not be greater than %d", v.MaxRetryAttempts)})
+		}
+		if retries.PerTryTimeout != nil && retries.PerTryTimeout.Duration <= 0 {
+			problems = append(problems, Failure{AttributePath: attributePath + ".retries.perTryTimeout", Message: "value must be greater than 0"})
+		}
+	}
+
+	effectiveTimeout := helpers.FindTimeout(api, rule, time.Second*time.Duration(v.HTTPTimeoutDuration))
+	if retries.PerTryTimeout != nil && effectiveTimeout > 0 && retries.PerTryTimeout.Duration > effectiveTimeout {
+		problems = append(problems, Failure{AttributePath: attributePath + ".retries.perTryTimeout", Message: fmt.Sprintf("value must not be greater than the timeout of %s", effectiveTimeout)})
+	}
+
+	return problems
+}
+
+func (v *APIRuleValidator) validateCorsPolicy(attributePath string, policy *gatewayv1beta1.CorsPolicy) []Failure {
+	var problems []Failure
+
+	allowsAllOrigins := false
+	for i, origin := range policy.AllowOrigins {
+		if countSet(origin.Exact, origin.Prefix, origin.Regex) != 1 {
+			problems = append(problems, Failure{AttributePath: fmt.Sprintf("%s.allowOrigins[%d]", attributePath, i), Message: "exactly one of exact, prefix or regex must be set"})
+		}
+		if origin.Exact == "*" || origin.Regex == ".*" {
+			allowsAllOrigins = true
+		}
+	}
+
+	// Browsers reject credentials for a wildcard origin, so the combination would silently break the cross-origin requests
+	if allowsAllOrigins && policy.AllowCredentials != nil && *policy.AllowCredentials {
+		problems = append(problems, Failure{AttributePath: attributePath + ".allowCredentials", Message: "credentials must not be allowed for all origins"})
+	}
+
+	problems = append(problems, v.validateMethods(attributePath+".allowMethods", policy.AllowMethods)...)
+
+	if policy.MaxAge != nil && policy.MaxAge.Duration < 0 {
+		problems = append(problems, Failure{AttributePath: attributePath + ".maxAge", Message: "value must not be negative"})
+	}
+
+	return problems
+}
+
+func countSet(values ...string) int {
+	count := 0
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// validateQuota checks that the APIRule stays within the configured quota. The usage is counted from the Virtual Services
+// owned by APIRules, so APIRules that are already exposed are not rejected if the quota is reduced later, as long as they
+// don't expose more than before.
+func (v *APIRuleValidator) validateQuota(attributePath string, vsList networkingv1beta1.VirtualServiceList, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+
+	exposedAPIRules := make(map[string]bool)
+	exposedRules := 0
+	for _, vs := range vsList.Items {
+		if ownedBy(vs, api) {
+			exposedRules = len(vs.Spec.Http)
+			continue
+		}
+		if owner, ok := ownerInNamespace(vs, api.Namespace); ok {
+			exposedAPIRules[owner] = true
+		}
+	}
+
+	maxAPIRules := v.Quota.MaxAPIRulesPerNamespace
+	if maxAPIRules > 0 && len(exposedAPIRules) >= maxAPIRules && exposedRules == 0 {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".host",
+			Message:       fmt.Sprintf("Namespace %s already exposes %d of %d allowed APIRules", api.Namespace, len(exposedAPIRules), maxAPIRules),
+		})
+	}
+
+	maxRules := v.Quota.MaxRulesPerAPIRule
+	if maxRules > 0 && len(api.Spec.Rules) > maxRules && len(api.Spec.Rules) > exposedRules {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".rules",
+			Message:       fmt.Sprintf("APIRule defines %d rules, but only %d are allowed", len(api.Spec.Rules), maxRules),
+		})
+	}
+
+	return problems
+}
+
+// validateExposure checks the exposed service against the exposure policy of the configuration. The policy is read from
+// the ConfigMap on every reconciliation, so changes apply without restarting the controller.
+func (v *APIRuleValidator) validateExposure(attributePath string, api *gatewayv1beta1.APIRule, service *gatewayv1beta1.Service, namespace string) []Failure {
+	var problems []Failure
+
+	if service.Name != nil && slices.Contains(v.Exposure.BlockedServices, *service.Name) {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".name",
+			Message:       fmt.Sprintf("Service %s must not be exposed", *service.Name),
+		})
+	}
+
+	if slices.Contains(v.Exposure.BlockedNamespaces, namespace) {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".namespace",
+			Message:       fmt.Sprintf("Services in namespace %s must not be exposed", namespace),
+		})
+	} else if v.Exposure.RestrictCrossNamespaceTargets && namespace != api.Namespace && !slices.Contains(v.Exposure.AllowedTargetNamespaces, namespace) {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".namespace",
+			Message:       fmt.Sprintf("Services in namespace %s must not be exposed by APIRules in namespace %s", namespace, api.Namespace),
+		})
+	}
+
+	if len(v.Exposure.AllowedPorts) > 0 && service.Port != nil && !isAllowedPort(v.Exposure.AllowedPorts, *service.Port) {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".port",
+			Message:       fmt.Sprintf("Port %d is not allowed to be exposed", *service.Port),
+		})
+	}
+
+	return problems
+}
+
+// hasAllowedSuffix returns true if the host is one of the suffixes or a subdomain of it. The suffix "example.com" doesn't
+// allow "badexample.com".
+func hasAllowedSuffix(suffixes []string, host string) bool {
+	for _, suffix := range suffixes {
+		suffix = strings.TrimPrefix(suffix, ".")
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowedPort(allowedPorts []uint32, port uint32) bool {
+	for _, allowed := range allowedPorts {
+		if allowed == port {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *APIRuleValidator) validateFault(attributePath string, fault *gatewayv1beta1.Fault) []Failure {
+	var problems []Failure
+
+	if !v.FaultInjectionEnabled {
+		problems = append(problems, Failure{AttributePath: attributePath, Message: "Fault injection is not enabled"})
+		return problems
+	}
+
+	if fault.Delay == nil && fault.Abort == nil {
+		problems = append(problems, Failure{AttributePath: attributePath, Message: "Fault must define a delay or an abort"})
+	}
+
+	if fault.Delay != nil {
+		if fault.Delay.FixedDelay.Duration <= 0 {
+			problems = append(problems, Failure{AttributePath: attributePath + ".delay.fixedDelay", Message: "value must be greater than 0"})
+		}
+		if !isValidPercentage(fault.Delay.Percentage) {
+			problems = append(problems, Failure{AttributePath: attributePath + ".delay.percentage", Message: "value must be between 0 and 100"})
+		}
+	}
+
+	if fault.Abort != nil {
+		if fault.Abort.HttpStatus < 400 || fault.Abort.HttpStatus > 599 {
+			problems = append(problems, Failure{AttributePath: attributePath + ".abort.httpStatus", Message: "value must be an HTTP error status code between 400 and 599"})
+		}
+		if !isValidPercentage(fault.Abort.Percentage) {
+			problems = append(problems, Failure{AttributePath: attributePath + ".abort.percentage", Message: "value must be between 0 and 100"})
+		}
+	}
+
+	return problems
+}
+
+func isValidPercentage(percentage int32) bool {
+	return percentage >= 0 && percentage <= 100
+}
+
 func (v *APIRuleValidator) validateMethods(attributePath string, methods []string) []Failure {
-	return nil
+	var problems []Failure
+
+	// Methods are upper cased before the subresources are generated, so the spelling doesn't matter here
+	seen := make(map[string]bool)
+	for i, method := range methods {
+		method = strings.ToUpper(method)
+		if !slices.Contains(supportedMethods, method) {
+			problems = append(problems, Failure{AttributePath: fmt.Sprintf("%s[%d]", attributePath, i), Message: fmt.Sprintf("Method %s is not a supported HTTP method", methods[i])})
+		}
+		if seen[method] {
+			problems = append(problems, Failure{AttributePath: fmt.Sprintf("%s[%d]", attributePath, i), Message: fmt.Sprintf("Method %s is duplicated", methods[i])})
+		}
+		seen[method] = true
+	}
+
+	return problems
 }
 
 func (v *APIRuleValidator) validateAccessStrategies(attributePath string, accessStrategies []*gatewayv1beta1.Authenticator, selector *apiv1beta1.WorkloadSelector, namespace string) []Failure {
@@ -295,6 +775,15 @@ func occupiesHost(vs *networkingv1beta1.VirtualService, host string) bool {
 	return false
 }
 
+// occupiedHostMessage names the APIRule owning the Virtual Service, so that the user knows which resource blocks the host.
+func occupiedHostMessage(vs *networkingv1beta1.VirtualService) string {
+	OwnerLabelv1alpha1 := fmt.Sprintf("%s.%s", "apirule", gatewayv1alpha1.GroupVersion.String())
+	if owner, ok := vs.GetLabels()[OwnerLabelv1alpha1]; ok {
+		return fmt.Sprintf("This host is occupied by another Virtual Service owned by APIRule %s", owner)
+	}
+	return "This host is occupied by another Virtual Service"
+}
+
 func getOwnerLabels(api *gatewayv1beta1.APIRule) map[string]string {
 	OwnerLabelv1alpha1 := fmt.Sprintf("%s.%s", "apirule", gatewayv1alpha1.GroupVersion.String())
 	labels := make(map[string]string)
@@ -302,9 +791,22 @@ func getOwnerLabels(api *gatewayv1beta1.APIRule) map[string]string {
 	return labels
 }
 
-func ownedBy(vs *networkingv1beta1.VirtualService, api *gatewayv1beta1.APIRule) bool {
+// ownerInNamespace returns the owner label value of the Virtual Service if it is owned by an APIRule in the given namespace.
+func ownerInNamespace(vs *networkingv1beta1.VirtualService, namespace string) (string, bool) {
+	OwnerLabelv1alpha1 := fmt.Sprintf("%s.%s", "apirule", gatewayv1alpha1.GroupVersion.String())
+	owner, ok := vs.GetLabels()[OwnerLabelv1alpha1]
+	if !ok {
+		return "", false
+	}
+	if _, ownerNamespace, ok := parseOwner(owner); !ok || ownerNamespace != namespace {
+		return "", false
+	}
+	return owner, true
+}
+
+func ownedBy(obj metav1.Object, api *gatewayv1beta1.APIRule) bool {
 	ownerLabels := getOwnerLabels(api)
-	vsLabels := vs.GetLabels()
+	vsLabels := obj.GetLabels()
 
 	for key, label := range ownerLabels {
 		val, ok := vsLabels[key]
@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
+	apinetworkingv1beta1 "istio.io/api/networking/v1beta1"
+	apiv1beta1 "istio.io/api/type/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	telemetryv1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"testing"
 
@@ -62,6 +68,58 @@ var _ = Describe("ValidateConfig function", func() {
 		Expect(problems).To(HaveLen(1))
 		Expect(problems[0].Message).To(Equal("Unsupported JWT Handler: foo"))
 	})
+
+	It("Should fail for empty scope claim name", func() {
+		//given
+		input := &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO, ScopeClaims: []string{"scp", ""}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateConfig(input)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("scopeClaims[1]"))
+		Expect(problems[0].Message).To(Equal("Scope claim name cannot be empty"))
+	})
+
+	It("Should fail for preset access strategy without handler", func() {
+		//given
+		input := &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO, AccessStrategyPresets: map[string][]helpers.AccessStrategyPreset{
+			"team-a": {{Handler: "noop"}, {}},
+		}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateConfig(input)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("accessStrategyPresets.team-a[1].handler"))
+		Expect(problems[0].Message).To(Equal("Handler of the preset access strategy cannot be empty"))
+	})
+
+	It("Should fail for unsupported trailing slash policy", func() {
+		//given
+		input := &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO, TrailingSlashPolicy: "append"}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateConfig(input)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("trailingSlashPolicy"))
+		Expect(problems[0].Message).To(Equal("Unsupported trailing slash policy: append"))
+	})
+
+	It("Should succeed for config with scope claim names", func() {
+		//given
+		input := &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO, ScopeClaims: []string{"scp", "roles"}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateConfig(input)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
 })
 
 var _ = Describe("Validate function", func() {
@@ -490,6 +548,77 @@ var _ = Describe("Validate function", func() {
 		Expect(problems[0].Message).To(Equal("This host is occupied by another Virtual Service"))
 	})
 
+	It("Should name the APIRule owning the VS that occupies the host", func() {
+		//given
+		occupiedHost := "occupied-host" + allowlistedDomain
+		existingVS := networkingv1beta1.VirtualService{}
+		existingVS.Labels = getOwnerLabels(&gatewayv1beta1.APIRule{ObjectMeta: v1.ObjectMeta{Name: "other-rule", Namespace: "other-ns"}})
+		existingVS.Spec.Hosts = []string{occupiedHost}
+
+		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "some-rule",
+				Namespace: "some-ns",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(occupiedHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+					},
+				},
+			},
+		}
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
+
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("This host is occupied by another Virtual Service owned by APIRule other-rule.other-ns"))
+	})
+
+	It("Should NOT fail for a host that is occupied by a VS that is being deleted", func() {
+		//given
+		occupiedHost := "occupied-host" + allowlistedDomain
+		deletionTimestamp := v1.Now()
+		existingVS := networkingv1beta1.VirtualService{}
+		existingVS.DeletionTimestamp = &deletionTimestamp
+		existingVS.Spec.Hosts = []string{occupiedHost}
+
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(occupiedHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+					},
+				},
+			},
+		}
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
+
+		Expect(problems).To(HaveLen(0))
+	})
+
 	It("Should NOT fail for a host that is occupied by a VS exposed by this resource", func() {
 		//given
 		occupiedHost := "occupied-host" + allowlistedDomain
@@ -841,6 +970,1087 @@ var _ = Describe("Validate function", func() {
 	})
 })
 
+var _ = Describe("Validate function for methods", func() {
+	methodsAPIRule := func(methods ...string) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+						Methods: methods,
+					},
+				},
+			},
+		}
+	}
+
+	It("Should succeed for all supported methods", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(methodsAPIRule("GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed for lower case method", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(methodsAPIRule("GET", "post"), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for unknown method", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(methodsAPIRule("FETCH"), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].methods[0]"))
+		Expect(problems[0].Message).To(Equal("Method FETCH is not a supported HTTP method"))
+	})
+
+	It("Should fail for duplicated method", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(methodsAPIRule("GET", "GET"), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].methods[1]"))
+		Expect(problems[0].Message).To(Equal("Method GET is duplicated"))
+	})
+
+	It("Should fail for method that is duplicated in another case", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(methodsAPIRule("GET", "get"), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].methods[1]"))
+		Expect(problems[0].Message).To(Equal("Method get is duplicated"))
+	})
+})
+
+var _ = Describe("Validate function with APIRules sharing a host", func() {
+	sharedHost := "shared-host." + allowlistedDomain
+	older := v1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := v1.NewTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	apiRuleCreatedAt := func(name string, creationTimestamp v1.Time) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              name,
+				Namespace:         "some-ns",
+				CreationTimestamp: creationTimestamp,
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sharedHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	vsOwnedBy := func(api *gatewayv1beta1.APIRule) *networkingv1beta1.VirtualService {
+		vs := &networkingv1beta1.VirtualService{}
+		vs.Labels = getOwnerLabels(api)
+		vs.Spec.Hosts = []string{sharedHost}
+		return vs
+	}
+
+	validate := func(input *gatewayv1beta1.APIRule, apiRules []gatewayv1beta1.APIRule, vsList ...*networkingv1beta1.VirtualService) []Failure {
+		return (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+			DefaultDomainName:         allowlistedDomain,
+			APIRules:                  apiRules,
+		}).Validate(input, networkingv1beta1.VirtualServiceList{Items: vsList})
+	}
+
+	It("Should fail for a host that is occupied by a VS of an older APIRule", func() {
+		//given
+		owner := apiRuleCreatedAt("older-rule", older)
+		input := apiRuleCreatedAt("newer-rule", newer)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*owner, *input}, vsOwnedBy(owner))
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("This host is occupied by another Virtual Service owned by APIRule older-rule.some-ns"))
+	})
+
+	It("Should fail for an older APIRule with the host of a VS of a newer APIRule", func() {
+		//given
+		owner := apiRuleCreatedAt("newer-rule", newer)
+		input := apiRuleCreatedAt("older-rule", older)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*owner, *input}, vsOwnedBy(owner))
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("This host is occupied by another Virtual Service owned by APIRule newer-rule.some-ns"))
+	})
+
+	It("Should NOT fail for a newer APIRule that has a VS for the host of an older APIRule", func() {
+		//given
+		other := apiRuleCreatedAt("older-rule", older)
+		input := apiRuleCreatedAt("newer-rule", newer)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*other, *input}, vsOwnedBy(input))
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should NOT fail for a host that is occupied by a VS of an older APIRule that is being deleted", func() {
+		//given
+		deletionTimestamp := v1.Now()
+		owner := apiRuleCreatedAt("older-rule", older)
+		owner.DeletionTimestamp = &deletionTimestamp
+		input := apiRuleCreatedAt("newer-rule", newer)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*owner, *input}, vsOwnedBy(owner))
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should NOT fail for a host that is occupied by a VS of an APIRule with dots in its name that is being deleted", func() {
+		//given
+		deletionTimestamp := v1.Now()
+		owner := apiRuleCreatedAt("older.rule", older)
+		owner.DeletionTimestamp = &deletionTimestamp
+		input := apiRuleCreatedAt("newer-rule", newer)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*owner, *input}, vsOwnedBy(owner))
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a host of an older APIRule that has no VS yet", func() {
+		//given
+		other := apiRuleCreatedAt("older-rule", older)
+		other.Spec.Host = getHost("shared-host")
+		input := apiRuleCreatedAt("newer-rule", newer)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*other, *input})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("This host is already used by the older APIRule older-rule.some-ns"))
+	})
+
+	It("Should NOT fail for a host of a newer APIRule that has no VS yet", func() {
+		//given
+		other := apiRuleCreatedAt("newer-rule", newer)
+		input := apiRuleCreatedAt("older-rule", older)
+
+		//when
+		problems := validate(input, []gatewayv1beta1.APIRule{*other, *input})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should keep the host for the APIRule with the lower name if both were created at the same time", func() {
+		//given
+		first := apiRuleCreatedAt("a-rule", older)
+		second := apiRuleCreatedAt("b-rule", older)
+		apiRules := []gatewayv1beta1.APIRule{*first, *second}
+
+		//when
+		firstProblems := validate(first, apiRules)
+		secondProblems := validate(second, apiRules)
+
+		//then
+		Expect(firstProblems).To(BeEmpty())
+		Expect(secondProblems).To(HaveLen(1))
+		Expect(secondProblems[0].Message).To(Equal("This host is already used by the older APIRule a-rule.some-ns"))
+	})
+})
+
+var _ = Describe("Validate function with quota", func() {
+	quotaAPIRule := func(name string, paths ...string) *gatewayv1beta1.APIRule {
+		var rules []gatewayv1beta1.Rule
+		for _, path := range paths {
+			rules = append(rules, gatewayv1beta1.Rule{
+				Path: path,
+				AccessStrategies: []*gatewayv1beta1.Authenticator{
+					toAuthenticator("allow", nil),
+				},
+			})
+		}
+		return &gatewayv1beta1.APIRule{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: "quota-ns",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(name + ".test.dev"),
+				Rules:   rules,
+			},
+		}
+	}
+
+	exposedVS := func(api *gatewayv1beta1.APIRule) *networkingv1beta1.VirtualService {
+		vs := &networkingv1beta1.VirtualService{}
+		vs.Labels = getOwnerLabels(api)
+		vs.Spec.Hosts = []string{*api.Spec.Host}
+		for range api.Spec.Rules {
+			vs.Spec.Http = append(vs.Spec.Http, &apinetworkingv1beta1.HTTPRoute{})
+		}
+		return vs
+	}
+
+	validator := func(quota helpers.QuotaConfig) *APIRuleValidator {
+		return &APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			Quota:                     quota,
+		}
+	}
+
+	It("Should succeed when namespace is under the APIRule limit", func() {
+		//given
+		existing := quotaAPIRule("existing", "/abc")
+		input := quotaAPIRule("new", "/abc")
+		vsList := networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{exposedVS(existing)}}
+
+		//when
+		problems := validator(helpers.QuotaConfig{MaxAPIRulesPerNamespace: 2}).Validate(input, vsList)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail when a new APIRule exceeds the APIRule limit of the namespace", func() {
+		//given
+		existing := quotaAPIRule("existing", "/abc")
+		otherNamespace := quotaAPIRule("other", "/abc")
+		otherNamespace.Namespace = "other-ns"
+		input := quotaAPIRule("new", "/abc")
+		vsList := networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{exposedVS(existing), exposedVS(otherNamespace)}}
+
+		//when
+		problems := validator(helpers.QuotaConfig{MaxAPIRulesPerNamespace: 1}).Validate(input, vsList)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("Namespace quota-ns already exposes 1 of 1 allowed APIRules"))
+	})
+
+	It("Should succeed for already exposed APIRule when the APIRule limit was reduced", func() {
+		//given
+		existing := quotaAPIRule("existing", "/abc")
+		input := quotaAPIRule("new", "/abc")
+		vsList := networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{exposedVS(existing), exposedVS(input)}}
+
+		//when
+		problems := validator(helpers.QuotaConfig{MaxAPIRulesPerNamespace: 1}).Validate(input, vsList)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should not count Virtual Services whose owner label doesn't name an APIRule of the namespace", func() {
+		//given
+		existing := quotaAPIRule("existing", "/abc")
+		noName := exposedVS(quotaAPIRule("no-name", "/abc"))
+		noName.Labels = map[string]string{"apirule.gateway.kyma-project.io/v1alpha1": ".quota-ns"}
+		input := quotaAPIRule("new", "/abc")
+		vsList := networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{exposedVS(existing), noName}}
+
+		//when
+		problems := validator(helpers.QuotaConfig{MaxAPIRulesPerNamespace: 2}).Validate(input, vsList)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail when APIRule exceeds the rule limit", func() {
+		//given
+		input := quotaAPIRule("new", "/abc", "/def")
+
+		//when
+		problems := validator(helpers.QuotaConfig{MaxRulesPerAPIRule: 1}).Validate(input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules"))
+		Expect(problems[0].Message).To(Equal("APIRule defines 2 rules, but only 1 are allowed"))
+	})
+
+	It("Should succeed for already exposed rules when the rule limit was reduced, but fail when rules are added", func() {
+		//given
+		exposed := quotaAPIRule("new", "/abc", "/def")
+		vsList := networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{exposedVS(exposed)}}
+
+		//when
+		unchangedProblems := validator(helpers.QuotaConfig{MaxRulesPerAPIRule: 1}).Validate(quotaAPIRule("new", "/abc", "/def"), vsList)
+		expandedProblems := validator(helpers.QuotaConfig{MaxRulesPerAPIRule: 1}).Validate(quotaAPIRule("new", "/abc", "/def", "/ghi"), vsList)
+
+		//then
+		Expect(unchangedProblems).To(BeEmpty())
+		Expect(expandedProblems).To(HaveLen(1))
+		Expect(expandedProblems[0].AttributePath).To(Equal(".spec.rules"))
+	})
+})
+
+var _ = Describe("Validate function with exposure policy", func() {
+	exposureAPIRule := func(serviceNamespace string, port uint32) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, port, &serviceNamespace),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	validator := func(exposure helpers.ExposureConfig) *APIRuleValidator {
+		return &APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			Exposure:                  exposure,
+		}
+	}
+
+	It("Should succeed with an empty policy", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{}).Validate(exposureAPIRule("kube-system", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a service in a blocked namespace", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{BlockedNamespaces: []string{"kube-system"}}).Validate(exposureAPIRule("kube-system", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.service.namespace"))
+		Expect(problems[0].Message).To(Equal("Services in namespace kube-system must not be exposed"))
+	})
+
+	It("Should fail for a blocked service name in any namespace", func() {
+		//given
+		input := exposureAPIRule("default", 8080)
+		input.Spec.Rules[0].Service = getService("kubernetes", uint32(443))
+
+		//when
+		problems := validator(helpers.ExposureConfig{BlockedServices: []string{"kubernetes"}}).Validate(input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].service.name"))
+		Expect(problems[0].Message).To(Equal("Service kubernetes must not be exposed"))
+	})
+
+	It("Should succeed for a host with an allowed host suffix", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{AllowedHostSuffixes: []string{"bar.foo", allowlistedDomain}}).Validate(exposureAPIRule("default", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a host without an allowed host suffix", func() {
+		//given
+		input := exposureAPIRule("default", 8080)
+		input.Spec.Host = getHost("httpbin.bad" + allowlistedDomain)
+
+		//when
+		problems := validator(helpers.ExposureConfig{AllowedHostSuffixes: []string{allowlistedDomain}}).Validate(input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("Host httpbin.badfoo.bar doesn't end with an allowed host suffix"))
+	})
+
+	It("Should check the allowed host suffixes against the host with the default domain", func() {
+		//given
+		input := exposureAPIRule("default", 8080)
+		input.Spec.Host = getHost("httpbin")
+		v := validator(helpers.ExposureConfig{AllowedHostSuffixes: []string{allowlistedDomain}})
+		v.DefaultDomainName = allowlistedDomain
+
+		//when
+		problems := v.Validate(input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a rule service with a port that is not allowed", func() {
+		//given
+		input := exposureAPIRule("default", 8080)
+		input.Spec.Rules[0].Service = getService("other-service", uint32(9090))
+
+		//when
+		problems := validator(helpers.ExposureConfig{AllowedPorts: []uint32{8080}}).Validate(input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].service.port"))
+		Expect(problems[0].Message).To(Equal("Port 9090 is not allowed to be exposed"))
+	})
+
+	It("Should succeed for a service in another namespace by default", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{}).Validate(exposureAPIRule("other", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a service in another namespace when cross-namespace targets are restricted", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{RestrictCrossNamespaceTargets: true}).Validate(exposureAPIRule("other", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.service.namespace"))
+		Expect(problems[0].Message).To(Equal("Services in namespace other must not be exposed by APIRules in namespace default"))
+	})
+
+	It("Should succeed for a service in the namespace of the APIRule when cross-namespace targets are restricted", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{RestrictCrossNamespaceTargets: true}).Validate(exposureAPIRule("default", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed for a service in an allowed target namespace", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{
+			RestrictCrossNamespaceTargets: true,
+			AllowedTargetNamespaces:       []string{"shared"},
+		}).Validate(exposureAPIRule("shared", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a rule service in another namespace that is not an allowed target namespace", func() {
+		//given
+		otherNamespace := "other"
+		input := exposureAPIRule("default", 8080)
+		input.Spec.Rules[0].Service = getService("other-service", uint32(8080), &otherNamespace)
+
+		//when
+		problems := validator(helpers.ExposureConfig{
+			RestrictCrossNamespaceTargets: true,
+			AllowedTargetNamespaces:       []string{"shared"},
+		}).Validate(input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].service.namespace"))
+		Expect(problems[0].Message).To(Equal("Services in namespace other must not be exposed by APIRules in namespace default"))
+	})
+
+	It("Should report all violations of the policy", func() {
+		//when
+		problems := validator(helpers.ExposureConfig{
+			BlockedNamespaces:   []string{"kube-system"},
+			BlockedServices:     []string{sampleServiceName},
+			AllowedHostSuffixes: []string{"example.com"},
+			AllowedPorts:        []uint32{80, 443},
+		}).Validate(exposureAPIRule("kube-system", 8080), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(4))
+		Expect(problems[0].AttributePath).To(Equal(".spec.service.name"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.service.namespace"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.service.port"))
+		Expect(problems[3].AttributePath).To(Equal(".spec.host"))
+	})
+})
+
+var _ = Describe("Validate function with CORS policy", func() {
+	corsAPIRule := func(policy *gatewayv1beta1.CorsPolicy) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+					},
+				},
+				CorsPolicy: policy,
+			},
+		}
+	}
+
+	validate := func(policy *gatewayv1beta1.CorsPolicy) []Failure {
+		return (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(corsAPIRule(policy), networkingv1beta1.VirtualServiceList{})
+	}
+
+	It("Should succeed for valid CORS policy", func() {
+		//given
+		allowCredentials := true
+
+		//when
+		problems := validate(&gatewayv1beta1.CorsPolicy{
+			AllowOrigins:     []gatewayv1beta1.StringMatch{{Exact: "https://example.com"}, {Regex: ".*\\.example\\.org"}},
+			AllowMethods:     []string{"GET", "POST"},
+			AllowCredentials: &allowCredentials,
+			MaxAge:           &v1.Duration{Duration: time.Hour},
+		})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	DescribeTable("Should fail for origin without exactly one match type",
+		func(origin gatewayv1beta1.StringMatch) {
+			//when
+			problems := validate(&gatewayv1beta1.CorsPolicy{AllowOrigins: []gatewayv1beta1.StringMatch{origin}})
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".spec.corsPolicy.allowOrigins[0]"))
+			Expect(problems[0].Message).To(Equal("exactly one of exact, prefix or regex must be set"))
+		},
+		Entry("no match type", gatewayv1beta1.StringMatch{}),
+		Entry("exact and prefix", gatewayv1beta1.StringMatch{Exact: "https://example.com", Prefix: "https://"}),
+	)
+
+	DescribeTable("Should fail for credentials allowed for all origins",
+		func(origin gatewayv1beta1.StringMatch) {
+			//given
+			allowCredentials := true
+
+			//when
+			problems := validate(&gatewayv1beta1.CorsPolicy{AllowOrigins: []gatewayv1beta1.StringMatch{origin}, AllowCredentials: &allowCredentials})
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".spec.corsPolicy.allowCredentials"))
+			Expect(problems[0].Message).To(Equal("credentials must not be allowed for all origins"))
+		},
+		Entry("exact wildcard", gatewayv1beta1.StringMatch{Exact: "*"}),
+		Entry("regex wildcard", gatewayv1beta1.StringMatch{Regex: ".*"}),
+	)
+
+	It("Should fail for unsupported method", func() {
+		//when
+		problems := validate(&gatewayv1beta1.CorsPolicy{AllowMethods: []string{"GET", "get"}})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.corsPolicy.allowMethods[1]"))
+	})
+})
+
+var _ = Describe("Validate function with timeout and retries", func() {
+	duration := func(d time.Duration) *v1.Duration {
+		return &v1.Duration{Duration: d}
+	}
+
+	retriesAPIRule := func(specTimeout *v1.Duration, specRetries *gatewayv1beta1.Retries, ruleTimeout *v1.Duration, ruleRetries *gatewayv1beta1.Retries) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Timeout: specTimeout,
+				Retries: specRetries,
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+						Timeout: ruleTimeout,
+						Retries: ruleRetries,
+					},
+				},
+			},
+		}
+	}
+
+	validator := &APIRuleValidator{
+		HandlerValidator:          handlerValidatorMock,
+		AccessStrategiesValidator: asValidatorMock,
+		HTTPTimeoutDuration:       180,
+		MaxRetryAttempts:          5,
+	}
+
+	DescribeTable("Should validate timeout and retries",
+		func(input *gatewayv1beta1.APIRule, expected []Failure) {
+			//when
+			problems := validator.Validate(input, networkingv1beta1.VirtualServiceList{})
+
+			//then
+			if expected == nil {
+				Expect(problems).To(BeEmpty())
+			} else {
+				Expect(problems).To(Equal(expected))
+			}
+		},
+		Entry("no timeout and retries",
+			retriesAPIRule(nil, nil, nil, nil), nil),
+		Entry("per try timeout within the default timeout",
+			retriesAPIRule(nil, &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: duration(time.Minute)}, nil, nil), nil),
+		Entry("per try timeout within the rule timeout",
+			retriesAPIRule(duration(10*time.Second), nil, duration(time.Minute), &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: duration(20 * time.Second)}), nil),
+		Entry("non-positive timeout",
+			retriesAPIRule(duration(0), nil, nil, nil),
+			[]Failure{{AttributePath: ".spec.timeout", Message: "value must be greater than 0"}}),
+		Entry("attempts above the limit",
+			retriesAPIRule(nil, nil, nil, &gatewayv1beta1.Retries{Attempts: 6}),
+			[]Failure{{AttributePath: ".spec.rules[0].retries.attempts", Message: "value must not be greater than 5"}}),
+		Entry("per try timeout greater than the spec timeout",
+			retriesAPIRule(duration(10*time.Second), &gatewayv1beta1.Retries{Attempts: 2, PerTryTimeout: duration(20 * time.Second)}, nil, nil),
+			[]Failure{{AttributePath: ".spec.retries.perTryTimeout", Message: "value must not be greater than the timeout of 10s"}}),
+		Entry("inherited per try timeout greater than the rule timeout",
+			retriesAPIRule(nil, &gatewayv1beta1.Retries{Attempts: 2, PerTryTimeout: duration(20 * time.Second)}, duration(5*time.Second), nil),
+			[]Failure{{AttributePath: ".spec.rules[0].retries.perTryTimeout", Message: "value must not be greater than the timeout of 5s"}}),
+	)
+})
+
+var _ = Describe("Validate function with tracing", func() {
+	tracingAPIRule := func(tracing *gatewayv1beta1.Tracing) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+					},
+				},
+				Tracing: tracing,
+			},
+		}
+	}
+
+	DescribeTable("Should validate sampling rate bounds",
+		func(samplingRate int32, valid bool) {
+			//when
+			problems := (&APIRuleValidator{
+				HandlerValidator:          handlerValidatorMock,
+				AccessStrategiesValidator: asValidatorMock,
+			}).Validate(tracingAPIRule(&gatewayv1beta1.Tracing{SamplingRate: samplingRate}), networkingv1beta1.VirtualServiceList{})
+
+			//then
+			if valid {
+				Expect(problems).To(BeEmpty())
+			} else {
+				Expect(problems).To(HaveLen(1))
+				Expect(problems[0].AttributePath).To(Equal(".spec.tracing.samplingRate"))
+				Expect(problems[0].Message).To(Equal("value must be between 0 and 100"))
+			}
+		},
+		Entry("0 is valid", int32(0), true),
+		Entry("100 is valid", int32(100), true),
+		Entry("-1 is invalid", int32(-1), false),
+		Entry("101 is invalid", int32(101), false),
+	)
+
+	It("Should fail for custom tag with empty name", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(tracingAPIRule(&gatewayv1beta1.Tracing{SamplingRate: 10, CustomTags: map[string]string{"": "value"}}), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.tracing.customTags"))
+		Expect(problems[0].Message).To(Equal("tag name must not be empty"))
+	})
+})
+
+var _ = Describe("ValidateHeadlessServices function", func() {
+	apiRuleFor := func(port uint32) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			ObjectMeta: v1.ObjectMeta{Name: "some-apirule", Namespace: "some-namespace"},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, port),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{Path: "/abc", AccessStrategies: []*gatewayv1beta1.Authenticator{toAuthenticator("allow", nil)}},
+				},
+			},
+		}
+	}
+
+	servicesWith := func(clusterIP string, ports ...corev1.ServicePort) map[string]*corev1.Service {
+		service := &corev1.Service{
+			ObjectMeta: v1.ObjectMeta{Name: sampleServiceName, Namespace: "some-namespace"},
+			Spec:       corev1.ServiceSpec{ClusterIP: clusterIP, Ports: ports},
+		}
+		return map[string]*corev1.Service{helpers.ServiceKey("some-namespace", sampleServiceName): service}
+	}
+
+	It("Should succeed for a headless Service whose port targets the same container port", func() {
+		//given
+		services := servicesWith(corev1.ClusterIPNone,
+			corev1.ServicePort{Port: 8080},
+			corev1.ServicePort{Port: 9090, TargetPort: intstr.FromInt(9090)},
+		)
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(8080), services)
+		problems = append(problems, (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(9090), services)...)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a headless Service whose port targets a named container port", func() {
+		//given
+		services := servicesWith(corev1.ClusterIPNone, corev1.ServicePort{Port: 8080, TargetPort: intstr.FromString("http")})
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(8080), services)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.service.port"))
+		Expect(problems[0].Message).To(Equal("Port 8080 of the headless Service some-namespace/some-service must target the same numeric container port, but targets http"))
+	})
+
+	It("Should fail for a headless Service whose port targets another container port", func() {
+		//given
+		services := servicesWith(corev1.ClusterIPNone, corev1.ServicePort{Port: 8080, TargetPort: intstr.FromInt(80)})
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(8080), services)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(Equal("Port 8080 of the headless Service some-namespace/some-service must target the same numeric container port, but targets 80"))
+	})
+
+	It("Should fail if the port is not a port of the headless Service", func() {
+		//given
+		services := servicesWith(corev1.ClusterIPNone, corev1.ServicePort{Port: 9090})
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(8080), services)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.service.port"))
+		Expect(problems[0].Message).To(Equal("Port 8080 is not a port of the headless Service some-namespace/some-service"))
+	})
+
+	It("Should succeed for a Service with cluster IP whose port targets a named container port", func() {
+		//given
+		services := servicesWith("10.0.0.1", corev1.ServicePort{Port: 8080, TargetPort: intstr.FromString("http")})
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(8080), services)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed if the Service doesn't exist", func() {
+		//when
+		problems := (&APIRuleValidator{}).ValidateHeadlessServices(apiRuleFor(8080), map[string]*corev1.Service{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ValidateTelemetries function", func() {
+	tracedAPIRule := func() *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			ObjectMeta: v1.ObjectMeta{Name: "some-apirule", Namespace: "some-namespace"},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{Path: "/abc", AccessStrategies: []*gatewayv1beta1.Authenticator{toAuthenticator("allow", nil)}},
+					{Path: "/def", AccessStrategies: []*gatewayv1beta1.Authenticator{toAuthenticator("allow", nil)}},
+				},
+				Tracing: &gatewayv1beta1.Tracing{SamplingRate: 10},
+			},
+		}
+	}
+
+	telemetryOf := func(owner string, namespace string, serviceName string) *telemetryv1alpha1.Telemetry {
+		telemetry := &telemetryv1alpha1.Telemetry{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      owner + "-telemetry",
+				Namespace: namespace,
+				Labels:    map[string]string{"apirule.gateway.kyma-project.io/v1alpha1": owner},
+			},
+		}
+		telemetry.Spec.Selector = &apiv1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": serviceName}}
+		return telemetry
+	}
+
+	It("Should fail if the workload has a Telemetry of another APIRule", func() {
+		//given
+		telemetries := telemetryv1alpha1.TelemetryList{Items: []*telemetryv1alpha1.Telemetry{telemetryOf("other-apirule.some-namespace", "some-namespace", sampleServiceName)}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateTelemetries(tracedAPIRule(), telemetries)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.service"))
+		Expect(problems[0].Message).To(Equal("The workload already has a Telemetry for tracing or access logging owned by APIRule other-apirule.some-namespace"))
+	})
+
+	It("Should fail if the workload has a Telemetry of another APIRule and the APIRule only configures access logging", func() {
+		//given
+		apiRule := tracedAPIRule()
+		apiRule.Spec.Tracing = nil
+		apiRule.Spec.AccessLogging = &gatewayv1beta1.AccessLogging{}
+		telemetries := telemetryv1alpha1.TelemetryList{Items: []*telemetryv1alpha1.Telemetry{telemetryOf("other-apirule.some-namespace", "some-namespace", sampleServiceName)}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateTelemetries(apiRule, telemetries)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+	})
+
+	It("Should succeed if the Telemetry is owned by the APIRule", func() {
+		//given
+		telemetries := telemetryv1alpha1.TelemetryList{Items: []*telemetryv1alpha1.Telemetry{telemetryOf("some-apirule.some-namespace", "some-namespace", sampleServiceName)}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateTelemetries(tracedAPIRule(), telemetries)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed if the Telemetry of another APIRule selects another workload", func() {
+		//given
+		telemetries := telemetryv1alpha1.TelemetryList{Items: []*telemetryv1alpha1.Telemetry{
+			telemetryOf("other-apirule.some-namespace", "some-namespace", "other-service"),
+			telemetryOf("other-apirule.other-namespace", "other-namespace", sampleServiceName),
+		}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateTelemetries(tracedAPIRule(), telemetries)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed if the Telemetry of another APIRule is being deleted", func() {
+		//given
+		telemetry := telemetryOf("other-apirule.some-namespace", "some-namespace", sampleServiceName)
+		deletionTimestamp := v1.Now()
+		telemetry.DeletionTimestamp = &deletionTimestamp
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateTelemetries(tracedAPIRule(), telemetryv1alpha1.TelemetryList{Items: []*telemetryv1alpha1.Telemetry{telemetry}})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed if neither tracing nor access logging is configured", func() {
+		//given
+		apiRule := tracedAPIRule()
+		apiRule.Spec.Tracing = nil
+		telemetries := telemetryv1alpha1.TelemetryList{Items: []*telemetryv1alpha1.Telemetry{telemetryOf("other-apirule.some-namespace", "some-namespace", sampleServiceName)}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateTelemetries(apiRule, telemetries)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Validate function with fault injection", func() {
+	faultAPIRule := func(fault *gatewayv1beta1.Fault) *gatewayv1beta1.APIRule {
+		return &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+						Methods: []string{"GET"},
+						Fault:   fault,
+					},
+				},
+			},
+		}
+	}
+
+	validFault := func() *gatewayv1beta1.Fault {
+		return &gatewayv1beta1.Fault{
+			Delay: &gatewayv1beta1.FaultDelay{FixedDelay: v1.Duration{Duration: 5 * time.Second}, Percentage: 10},
+			Abort: &gatewayv1beta1.FaultAbort{HttpStatus: 503, Percentage: 5},
+		}
+	}
+
+	It("Should fail when fault injection is not enabled", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+		}).Validate(faultAPIRule(validFault()), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].fault"))
+		Expect(problems[0].Message).To(Equal("Fault injection is not enabled"))
+	})
+
+	It("Should succeed for valid fault when fault injection is enabled", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			FaultInjectionEnabled:     true,
+		}).Validate(faultAPIRule(validFault()), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for fault without delay and abort", func() {
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			FaultInjectionEnabled:     true,
+		}).Validate(faultAPIRule(&gatewayv1beta1.Fault{}), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].fault"))
+		Expect(problems[0].Message).To(Equal("Fault must define a delay or an abort"))
+	})
+
+	It("Should fail for percentages outside of 0 to 100", func() {
+		//given
+		fault := validFault()
+		fault.Delay.Percentage = 101
+		fault.Abort.Percentage = -1
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			FaultInjectionEnabled:     true,
+		}).Validate(faultAPIRule(fault), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].fault.delay.percentage"))
+		Expect(problems[0].Message).To(Equal("value must be between 0 and 100"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].fault.abort.percentage"))
+		Expect(problems[1].Message).To(Equal("value must be between 0 and 100"))
+	})
+
+	It("Should fail for abort with non-error status code", func() {
+		//given
+		fault := validFault()
+		fault.Abort.HttpStatus = 200
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			FaultInjectionEnabled:     true,
+		}).Validate(faultAPIRule(fault), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].fault.abort.httpStatus"))
+		Expect(problems[0].Message).To(Equal("value must be an HTTP error status code between 400 and 599"))
+	})
+
+	It("Should fail for delay without duration", func() {
+		//given
+		fault := validFault()
+		fault.Delay.FixedDelay = v1.Duration{}
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			FaultInjectionEnabled:     true,
+		}).Validate(faultAPIRule(fault), networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].fault.delay.fixedDelay"))
+		Expect(problems[0].Message).To(Equal("value must be greater than 0"))
+	})
+})
+
 var _ = Describe("Validator for", func() {
 	Describe("NoConfig access strategy", func() {
 		It("Should fail with non-empty config", func() {